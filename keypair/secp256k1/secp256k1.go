@@ -1,26 +1,81 @@
 package secp256k1
 
 import (
-	"crypto/x509"
+	"bytes"
+	"crypto/x509/pkix"
+	"encoding/asn1"
 	"encoding/hex"
 	"encoding/pem"
 	"fmt"
 
 	"github.com/casper-ecosystem/casper-golang-sdk/keypair"
+	"github.com/pkg/errors"
 	"github.com/tendermint/tendermint/crypto/secp256k1"
 	"golang.org/x/crypto/blake2b"
 )
 
-type secp256k1KeyPair struct {
-	seed       []byte
-	PublKey    []byte
+const SECP256K1_PEM_SECRET_KEY_TAG = "EC PRIVATE KEY"
+const SECP256K1_PEM_PUBLIC_KEY_TAG = "PUBLIC KEY"
+
+const (
+	compressedPublicKeySize   = 33
+	uncompressedPublicKeySize = 65
+)
+
+var (
+	oidPublicKeyECDSA      = asn1.ObjectIdentifier{1, 2, 840, 10045, 2, 1}
+	oidNamedCurveSecp256k1 = asn1.ObjectIdentifier{1, 3, 132, 0, 10}
+)
+
+// ecPrivateKey is the SEC 1 private key structure, written by casper-client as secret_key.pem
+type ecPrivateKey struct {
+	Version       int
+	PrivateKey    []byte
+	NamedCurveOID asn1.ObjectIdentifier `asn1:"optional,explicit,tag:0"`
+	PublicKey     asn1.BitString        `asn1:"optional,explicit,tag:1"`
+}
+
+// pkcs8PrivateKey is the PKCS #8 wrapper around an ecPrivateKey
+type pkcs8PrivateKey struct {
+	Version    int
+	Algo       pkix.AlgorithmIdentifier
 	PrivateKey []byte
 }
 
+// subjectPublicKeyInfo is the PKIX public key structure, written by casper-client as public_key.pem
+type subjectPublicKeyInfo struct {
+	Algorithm pkix.AlgorithmIdentifier
+	PublicKey asn1.BitString
+}
+
+// Secp256k1Random creates a random Secp256k1 keypair
 func Secp256k1Random() keypair.KeyPair {
 	priv := secp256k1.GenPrivKey()
-	pub := priv.PubKey().Bytes()
-	return &secp256k1KeyPair{PublKey: pub, PrivateKey: priv}
+	return newKeyPair(priv)
+}
+
+// Secp256k1FromPrivateKey creates the keypair of the provided 32 bytes Secp256k1 private key
+func Secp256k1FromPrivateKey(privateKey []byte) (keypair.KeyPair, error) {
+	if len(privateKey) != secp256k1.PrivKeySize {
+		return nil, errors.New("Unexpected key length")
+	}
+
+	return newKeyPair(append(make([]byte, 0), privateKey...)), nil
+}
+
+func newKeyPair(priv secp256k1.PrivKey) *secp256k1KeyPair {
+	return &secp256k1KeyPair{
+		seed:       priv,
+		PublKey:    priv.PubKey().Bytes(),
+		PrivateKey: priv,
+	}
+}
+
+// PublKey is the compressed 33 bytes public key, PrivateKey the 32 bytes private key
+type secp256k1KeyPair struct {
+	seed       []byte
+	PublKey    []byte
+	PrivateKey []byte
 }
 
 func (key *secp256k1KeyPair) RawSeed() []byte {
@@ -41,7 +96,8 @@ func (key *secp256k1KeyPair) PublicKey() keypair.PublicKey {
 	}
 }
 
-// Sign signs the message by using the keyPair
+// Sign signs the sha256 digest of the message by using the keyPair.
+// The signature is deterministic (RFC 6979) and encoded as the 64 bytes r || s with a low s
 func (key *secp256k1KeyPair) Sign(mes []byte) keypair.Signature {
 	_, privKey := key.keys()
 	sign, _ := privKey.Sign(mes)
@@ -52,26 +108,22 @@ func (key *secp256k1KeyPair) Sign(mes []byte) keypair.Signature {
 }
 
 // Verify verifies the signature along with the raw message
-func (key *secp256k1KeyPair) Verify(mes []byte, sign []byte) bool {
+func (key *secp256k1KeyPair) Verify(sign []byte, mes []byte) bool {
 	pubKey, _ := key.keys()
+	if len(pubKey) != secp256k1.PubKeySize {
+		return false
+	}
 	return pubKey.VerifySignature(mes, sign)
 }
 
 // AccountHash generates the accountHash for the Secp256K1 public key
 func (key *secp256k1KeyPair) AccountHash() string {
 	pubKey, _ := key.keys()
-	var buffer = append([]byte(keypair.StrKeyTagSecp256k1), keypair.Separator)
-	buffer = append(buffer, pubKey...)
-
-	hash := blake2b.Sum256(buffer)
-
-	return fmt.Sprintf("account-hash-%s", hex.EncodeToString(hash[:]))
+	return fmt.Sprintf("account-hash-%s", AccountHash(pubKey))
 }
 
 func (key *secp256k1KeyPair) keys() (secp256k1.PubKey, secp256k1.PrivKey) {
-	priv := secp256k1.GenPrivKey()
-	pub := priv.PubKey().Bytes()
-	return pub, priv
+	return key.PublKey, key.PrivateKey
 }
 
 // AccountHex generates the accountHex for the Secp256K1 public key
@@ -81,65 +133,179 @@ func AccountHex(publicKey []byte) string {
 	return "02" + string(dst)
 }
 
-// ExportPublicKeyInPem exports the public key encoded in pem
+// AccountHash generates the account hash for the compressed Secp256K1 public key
+func AccountHash(pubKey []byte) string {
+	buffer := append([]byte(keypair.StrKeyTagSecp256k1), keypair.Separator)
+	buffer = append(buffer, pubKey...)
+
+	hash := blake2b.Sum256(buffer)
+
+	return hex.EncodeToString(hash[:])
+}
+
+// ExportPublicKeyInPem exports the compressed or uncompressed public key encoded in pem
 func ExportPublicKeyInPem(publicKey []byte) []byte {
-	x509EncodedPub, _ := x509.MarshalPKIXPublicKey(publicKey)
-	pemEncodedPub := pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: x509EncodedPub})
+	der, err := asn1.Marshal(subjectPublicKeyInfo{
+		Algorithm: pkix.AlgorithmIdentifier{
+			Algorithm:  oidPublicKeyECDSA,
+			Parameters: asn1.RawValue{FullBytes: mustMarshal(oidNamedCurveSecp256k1)},
+		},
+		PublicKey: asn1.BitString{Bytes: publicKey, BitLength: 8 * len(publicKey)},
+	})
+	if err != nil {
+		return nil
+	}
 
-	return pemEncodedPub
+	return pem.EncodeToMemory(&pem.Block{Type: SECP256K1_PEM_PUBLIC_KEY_TAG, Bytes: der})
 }
 
-// ExportPrivateKeyInPem expects the private key encoded in pem
+// ExportPrivateKeyInPem exports the private key encoded in pem, in the SEC 1 format used by casper-client
 func ExportPrivateKeyInPem(privateKey []byte) []byte {
-	x509Encoded, _ := x509.MarshalPKCS8PrivateKey(privateKey)
-	pemEncoded := pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: x509Encoded})
+	if len(privateKey) != secp256k1.PrivKeySize {
+		return nil
+	}
 
-	return pemEncoded
+	publicKey := secp256k1.PrivKey(privateKey).PubKey().Bytes()
+
+	der, err := asn1.Marshal(ecPrivateKey{
+		Version:       1,
+		PrivateKey:    privateKey,
+		NamedCurveOID: oidNamedCurveSecp256k1,
+		PublicKey:     asn1.BitString{Bytes: publicKey, BitLength: 8 * len(publicKey)},
+	})
+	if err != nil {
+		return nil
+	}
+
+	return pem.EncodeToMemory(&pem.Block{Type: SECP256K1_PEM_SECRET_KEY_TAG, Bytes: der})
+}
+
+// ParsePublicKey parses a DER encoded PKIX public key and returns it in the 33 bytes compressed form
+func ParsePublicKey(der []byte) ([]byte, error) {
+	var info subjectPublicKeyInfo
+	rest, err := asn1.Unmarshal(der, &info)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to parse public key")
+	}
+	if len(rest) != 0 {
+		return nil, errors.New("trailing data after public key")
+	}
+
+	if !info.Algorithm.Algorithm.Equal(oidPublicKeyECDSA) {
+		return nil, errors.New("public key is not an elliptic curve key")
+	}
+
+	var curve asn1.ObjectIdentifier
+	if _, err := asn1.Unmarshal(info.Algorithm.Parameters.FullBytes, &curve); err != nil || !curve.Equal(oidNamedCurveSecp256k1) {
+		return nil, errors.New("public key is not a secp256k1 key")
+	}
+
+	return compressPublicKey(info.PublicKey.RightAlign())
 }
 
-func ParsePublicKey(pemEncodedPub string) []byte {
-	blockPub, _ := pem.Decode([]byte(pemEncodedPub))
-	x509EncodedPub := blockPub.Bytes
-	genericPublicKey, _ := x509.ParsePKIXPublicKey(x509EncodedPub)
-	publicKey := genericPublicKey.([]byte)
+// ParsePrivateKey parses a DER encoded SEC 1 or PKCS #8 private key and returns the 32 bytes private key
+func ParsePrivateKey(der []byte) ([]byte, error) {
+	var pkcs8 pkcs8PrivateKey
+	if _, err := asn1.Unmarshal(der, &pkcs8); err == nil && pkcs8.Algo.Algorithm.Equal(oidPublicKeyECDSA) {
+		der = pkcs8.PrivateKey
+	}
+
+	var key ecPrivateKey
+	rest, err := asn1.Unmarshal(der, &key)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to parse private key")
+	}
+	if len(rest) != 0 {
+		return nil, errors.New("trailing data after private key")
+	}
+
+	if key.Version != 1 {
+		return nil, fmt.Errorf("unknown private key version %d", key.Version)
+	}
+	if len(key.NamedCurveOID) != 0 && !key.NamedCurveOID.Equal(oidNamedCurveSecp256k1) {
+		return nil, errors.New("private key is not a secp256k1 key")
+	}
+
+	// leading zeros may be stripped by some encoders
+	if len(key.PrivateKey) > secp256k1.PrivKeySize {
+		return nil, errors.New("Unexpected key length")
+	}
+	privateKey := make([]byte, secp256k1.PrivKeySize)
+	copy(privateKey[secp256k1.PrivKeySize-len(key.PrivateKey):], key.PrivateKey)
 
-	return publicKey
+	return privateKey, nil
 }
 
-func ParsePrivateKey(pemEncoded string) []byte {
-	block, _ := pem.Decode([]byte(pemEncoded))
-	x509Encoded := block.Bytes
-	privateKey, _ := x509.ParseECPrivateKey(x509Encoded)
-	res, _ := x509.MarshalECPrivateKey(privateKey)
-	return res
+// ParseKeyPair constructs keyPair from a compressed public key and a private key, which have to match
+func ParseKeyPair(publicKey []byte, privateKey []byte) (keypair.KeyPair, error) {
+	keyPair, err := Secp256k1FromPrivateKey(privateKey)
+	if err != nil {
+		return nil, err
+	}
+
+	if !bytes.Equal(keyPair.PublicKey().PubKeyData, publicKey) {
+		return nil, errors.New("public key does not match the private key")
+	}
+
+	return keyPair, nil
 }
 
-// ParseKeyPair constructs keyPair from public key and private key
-func ParseKeyPair(publicKey, privateKey string) keypair.KeyPair {
-	pub := ParsePublicKey(publicKey)
-	priv := ParsePrivateKey(privateKey)
-	keyPair := secp256k1KeyPair{PublKey: pub, PrivateKey: priv}
+func ParsePublicKeyFile(path string) ([]byte, error) {
+	key, err := keypair.ReadBase64File(path)
 
-	return &keyPair
+	if err != nil {
+		return nil, err
+	}
+
+	return ParsePublicKey(key)
 }
 
-func ParsePublicKeyFile(path string) []byte {
-	key, _ := keypair.ReadBase64File(path)
-	return ParsePublicKey(string(key))
+func ParsePrivateKeyFile(path string) ([]byte, error) {
+	key, err := keypair.ReadBase64File(path)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return ParsePrivateKey(key)
 }
 
-func ParsePrivateKeyFile(path string) []byte {
-	key, _ := keypair.ReadBase64File(path)
-	return ParsePrivateKey(string(key))
+// ParseKeyFiles parses the key pair from publicKey file and privateKey file
+func ParseKeyFiles(pubKeyPath, privKeyPath string) (keypair.KeyPair, error) {
+	pub, err := ParsePublicKeyFile(pubKeyPath)
+
+	if err != nil {
+		return nil, err
+	}
+
+	priv, err := ParsePrivateKeyFile(privKeyPath)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return ParseKeyPair(pub, priv)
 }
 
-func ParseKeyFiles(pubKeyPath, privKeyPath string) keypair.KeyPair {
-	pub := ParsePublicKeyFile(pubKeyPath)
-	priv := ParsePrivateKeyFile(privKeyPath)
+// compressPublicKey converts an uncompressed public key to the compressed form casper uses
+func compressPublicKey(publicKey []byte) ([]byte, error) {
+	switch {
+	case len(publicKey) == compressedPublicKeySize && (publicKey[0] == 2 || publicKey[0] == 3):
+		return publicKey, nil
+	case len(publicKey) == uncompressedPublicKeySize && publicKey[0] == 4:
+		compressed := make([]byte, compressedPublicKeySize)
+		compressed[0] = 2 | publicKey[uncompressedPublicKeySize-1]&1
+		copy(compressed[1:], publicKey[1:33])
+		return compressed, nil
+	}
 
-	keyPair := secp256k1KeyPair{
-		PublKey:    pub,
-		PrivateKey: priv,
+	return nil, errors.New("Unexpected key length")
+}
+
+func mustMarshal(val interface{}) []byte {
+	b, err := asn1.Marshal(val)
+	if err != nil {
+		panic(err)
 	}
-	return &keyPair
+	return b
 }
@@ -0,0 +1,112 @@
+package secp256k1
+
+import (
+	"encoding/hex"
+	"fmt"
+	"io/ioutil"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/crypto/blake2b"
+)
+
+const testPublicKeyPath = "../test_account_keys/account3/public_key.pem"
+const testSecretKeyPath = "../test_account_keys/account3/secret_key.pem"
+
+func TestSecp256k1KeyPair_ParseKeyFiles(t *testing.T) {
+	kp, err := ParseKeyFiles(testPublicKeyPath, testSecretKeyPath)
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	publicKeyHex, _ := ioutil.ReadFile("../test_account_keys/account3/public_key_hex")
+	assert.Equal(t, string(publicKeyHex), AccountHex(kp.PublicKey().PubKeyData))
+	assert.Equal(t, kp.PublicKey(), kp.PublicKey())
+}
+
+func TestSecp256k1KeyPair_ParseKeyFilesMismatch(t *testing.T) {
+	_, err := ParseKeyFiles(testPublicKeyPath, "../test_account_keys/account1/secret_key.pem")
+	assert.Error(t, err)
+
+	other := Secp256k1Random()
+	dir, _ := ioutil.TempDir("", "test")
+	fileName := dir + "/secret_key.pem"
+	err = ioutil.WriteFile(fileName, ExportPrivateKeyInPem(other.RawSeed()), 0644)
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	_, err = ParseKeyFiles(testPublicKeyPath, fileName)
+	assert.Error(t, err)
+}
+
+func TestSecp256k1KeyPair_AccountHash(t *testing.T) {
+	kp, err := ParseKeyFiles(testPublicKeyPath, testSecretKeyPath)
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	buffer := append([]byte("secp256k1"), 0)
+	buffer = append(buffer, kp.PublicKey().PubKeyData...)
+	hash := blake2b.Sum256(buffer)
+
+	assert.Equal(t, fmt.Sprintf("account-hash-%s", hex.EncodeToString(hash[:])), kp.AccountHash())
+	assert.Equal(t, kp.AccountHash(), kp.AccountHash())
+}
+
+func TestSecp256k1KeyPair_SignDeterministic(t *testing.T) {
+	kp, err := ParseKeyFiles(testPublicKeyPath, testSecretKeyPath)
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	message := []byte("hello world")
+	sign := kp.Sign(message)
+
+	assert.Equal(t, 64, len(sign.SignatureData))
+	assert.Equal(t, sign, kp.Sign(message))
+	assert.True(t, kp.Verify(sign.SignatureData, message))
+	assert.True(t, kp.PublicKey().Verify(message, sign))
+	assert.False(t, kp.Verify(sign.SignatureData, []byte("hello")))
+}
+
+func TestSecp256k1KeyPair_VerifyFalse(t *testing.T) {
+	kp := Secp256k1Random()
+	other := Secp256k1Random()
+	message := []byte("hello world")
+
+	assert.False(t, kp.Verify(other.Sign(message).SignatureData, message))
+}
+
+func TestSecp256k1KeyPair_ExportInPem(t *testing.T) {
+	kp := Secp256k1Random()
+
+	dir, _ := ioutil.TempDir("", "test")
+	fileNamePubl := dir + "/public_key.pem"
+	fileNamePriv := dir + "/secret_key.pem"
+
+	err := ioutil.WriteFile(fileNamePubl, ExportPublicKeyInPem(kp.PublicKey().PubKeyData), 0644)
+	if !assert.NoError(t, err) {
+		return
+	}
+	err = ioutil.WriteFile(fileNamePriv, ExportPrivateKeyInPem(kp.RawSeed()), 0644)
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	parsed, err := ParseKeyFiles(fileNamePubl, fileNamePriv)
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	assert.Equal(t, kp.PublicKey(), parsed.PublicKey())
+	assert.Equal(t, kp.Sign([]byte("hello world")), parsed.Sign([]byte("hello world")))
+}
+
+func TestSecp256k1KeyPair_ParsePublicKeyError(t *testing.T) {
+	_, err := ParsePublicKeyFile("../test_account_keys/account1/public_key.pem")
+	assert.Error(t, err)
+
+	_, err = Secp256k1FromPrivateKey([]byte{1, 2, 3})
+	assert.Error(t, err)
+}
@@ -2,9 +2,13 @@ package keypair
 
 import (
 	"bytes"
+	"crypto/ed25519"
 	"encoding/hex"
 	"encoding/json"
+	"errors"
 	"io"
+
+	"github.com/tendermint/tendermint/crypto/secp256k1"
 )
 
 type KeyTag byte
@@ -58,6 +62,10 @@ func (key *PublicKey) UnmarshalJSON(data []byte) error {
 		return err
 	}
 
+	if len(resultHex) == 0 {
+		return errors.New("empty public key")
+	}
+
 	key.Tag = KeyTag(resultHex[0])
 	key.PubKeyData = resultHex[1:]
 	return nil
@@ -94,3 +102,46 @@ func (signature Signature) MarshalJSON() ([]byte, error) {
 	}
 	return json.Marshal(hex.EncodeToString(w.Bytes()))
 }
+
+func (signature *Signature) UnmarshalJSON(data []byte) error {
+	var result string
+
+	if err := json.Unmarshal(data, &result); err != nil {
+		return err
+	}
+
+	resultHex, err := hex.DecodeString(result)
+	if err != nil {
+		return err
+	}
+
+	if len(resultHex) == 0 {
+		return errors.New("empty signature")
+	}
+
+	signature.Tag = KeyTag(resultHex[0])
+	signature.SignatureData = resultHex[1:]
+	return nil
+}
+
+// Verify checks that signature is a valid signature of the message made by the key
+func (key PublicKey) Verify(message []byte, signature Signature) bool {
+	if key.Tag != signature.Tag {
+		return false
+	}
+
+	switch key.Tag {
+	case KeyTagEd25519:
+		if len(key.PubKeyData) != ed25519.PublicKeySize {
+			return false
+		}
+		return ed25519.Verify(key.PubKeyData, message, signature.SignatureData)
+	case KeyTagSecp256k1:
+		if len(key.PubKeyData) != secp256k1.PubKeySize {
+			return false
+		}
+		return secp256k1.PubKey(key.PubKeyData).VerifySignature(message, signature.SignatureData)
+	}
+
+	return false
+}
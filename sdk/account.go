@@ -0,0 +1,151 @@
+package sdk
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/casper-ecosystem/casper-golang-sdk/keypair"
+	"github.com/casper-ecosystem/casper-golang-sdk/types"
+)
+
+// AccountIdentifier selects an account by its public key or by its account hash
+type AccountIdentifier struct {
+	PublicKey   *keypair.PublicKey
+	AccountHash string
+}
+
+// AccountByPublicKey selects an account by its public key
+func AccountByPublicKey(publicKey keypair.PublicKey) AccountIdentifier {
+	return AccountIdentifier{PublicKey: &publicKey}
+}
+
+// AccountByHash selects an account by its hex encoded account hash, with or without the account-hash- prefix
+func AccountByHash(accountHash string) AccountIdentifier {
+	return AccountIdentifier{AccountHash: accountHash}
+}
+
+// MarshalJSON encodes the public key in hex or the account hash as account-hash-<hex>
+func (a AccountIdentifier) MarshalJSON() ([]byte, error) {
+	if a.PublicKey != nil {
+		return json.Marshal(*a.PublicKey)
+	}
+	if a.AccountHash == "" {
+		return nil, errors.New("account identifier needs a PublicKey or an AccountHash")
+	}
+	return json.Marshal(types.AccountHashPrefix + strings.TrimPrefix(a.AccountHash, types.AccountHashPrefix))
+}
+
+type accountInfoResult struct {
+	Account JsonAccount `json:"account"`
+}
+
+func (c *RpcClient) GetAccountInfo(account AccountIdentifier, block BlockIdentifier) (Account, error) {
+	return c.GetAccountInfoContext(context.Background(), account, block)
+}
+
+// GetAccountInfoContext returns the account as of the given block, use the zero BlockIdentifier for the latest block.
+// Nodes before 1.5 only accept accounts identified by their public key
+func (c *RpcClient) GetAccountInfoContext(ctx context.Context, account AccountIdentifier, block BlockIdentifier) (Account, error) {
+	resp, err := c.rpcCall(ctx, "state_get_account_info", map[string]interface{}{
+		"public_key":       account,
+		"block_identifier": block,
+	})
+	if err != nil {
+		return Account{}, err
+	}
+
+	var result accountInfoResult
+	err = json.Unmarshal(resp.Result, &result)
+	if err != nil {
+		return Account{}, fmt.Errorf("failed to get result: %w", err)
+	}
+
+	return result.Account.ToAccount()
+}
+
+// Account is an account with parsed keys, see JsonAccount for the raw form returned by the node
+type Account struct {
+	AccountHash types.Key
+	NamedKeys   map[string]types.Key
+	MainPurse   types.URef
+	// AssociatedKeys maps the formatted account hash of each associated key to its weight
+	AssociatedKeys   map[string]uint64
+	ActionThresholds ActionThresholds
+}
+
+// ToAccount parses the account hash, named keys and main purse of the account
+func (a JsonAccount) ToAccount() (Account, error) {
+	accountHash, err := types.KeyFromFormattedString(a.AccountHash)
+	if err != nil {
+		return Account{}, fmt.Errorf("invalid account hash: %w", err)
+	}
+
+	mainPurse, err := types.URefFromFormattedString(a.MainPurse)
+	if err != nil {
+		return Account{}, fmt.Errorf("invalid main purse: %w", err)
+	}
+
+	account := Account{
+		AccountHash:      *accountHash,
+		NamedKeys:        make(map[string]types.Key, len(a.NamedKeys)),
+		MainPurse:        *mainPurse,
+		AssociatedKeys:   make(map[string]uint64, len(a.AssociatedKeys)),
+		ActionThresholds: a.ActionThresholds,
+	}
+
+	for _, namedKey := range a.NamedKeys {
+		key, err := types.KeyFromFormattedString(namedKey.Key)
+		if err != nil {
+			return Account{}, fmt.Errorf("invalid named key %q: %w", namedKey.Name, err)
+		}
+		account.NamedKeys[namedKey.Name] = *key
+	}
+
+	for _, associatedKey := range a.AssociatedKeys {
+		account.AssociatedKeys[associatedKey.AccountHash] = associatedKey.Weight
+	}
+
+	return account, nil
+}
+
+// NamedKey returns the key stored under name in the named keys of the account
+func (a Account) NamedKey(name string) (types.Key, bool) {
+	key, ok := a.NamedKeys[name]
+	return key, ok
+}
+
+// SignersWeight returns the summed weight of the signers which are associated keys of the account, each counted once
+func (a Account) SignersWeight(signers ...keypair.PublicKey) uint64 {
+	var weight uint64
+	seen := make(map[string]bool)
+
+	for _, signer := range signers {
+		hash, ok := accountHash(signer)
+		if !ok {
+			continue
+		}
+
+		formatted := types.AccountHashPrefix + hash
+		if seen[formatted] {
+			continue
+		}
+		seen[formatted] = true
+
+		weight += a.AssociatedKeys[formatted]
+	}
+
+	return weight
+}
+
+// CanDeploy reports whether a deploy approved by the signers meets the deployment threshold of the account
+func (a Account) CanDeploy(signers ...keypair.PublicKey) bool {
+	return a.SignersWeight(signers...) >= a.ActionThresholds.Deployment
+}
+
+// CanManageKeys reports whether the signers meet the key management threshold of the account
+func (a Account) CanManageKeys(signers ...keypair.PublicKey) bool {
+	return a.SignersWeight(signers...) >= a.ActionThresholds.KeyManagement
+}
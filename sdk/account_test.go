@@ -0,0 +1,102 @@
+package sdk
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/casper-ecosystem/casper-golang-sdk/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func testAccountJSON(t *testing.T) string {
+	sourceHash, _ := accountHash(*source)
+	destHash, _ := accountHash(*dest)
+
+	account := JsonAccount{
+		AccountHash: types.AccountHashPrefix + sourceHash,
+		NamedKeys: []NamedKey{
+			{Name: "counter", Key: "hash-" + strings.Repeat("2a", 32)},
+			{Name: "balances", Key: "uref-" + strings.Repeat("2b", 32) + "-007"},
+		},
+		MainPurse: "uref-" + strings.Repeat("2c", 32) + "-007",
+		AssociatedKeys: []AssociatedKey{
+			{AccountHash: types.AccountHashPrefix + sourceHash, Weight: 1},
+			{AccountHash: types.AccountHashPrefix + destHash, Weight: 2},
+		},
+		ActionThresholds: ActionThresholds{Deployment: 2, KeyManagement: 3},
+	}
+
+	data, err := json.Marshal(account)
+	assert.NoError(t, err)
+	return string(data)
+}
+
+func TestRpcClient_GetAccountInfo(t *testing.T) {
+	var requests []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var request struct {
+			Method string          `json:"method"`
+			Params json.RawMessage `json:"params"`
+		}
+		json.NewDecoder(r.Body).Decode(&request)
+		assert.Equal(t, "state_get_account_info", request.Method)
+		requests = append(requests, string(request.Params))
+
+		w.Write([]byte(`{"jsonrpc":"2.0","id":"1","result":{"api_version":"1.4.3","account":` + testAccountJSON(t) + `,"merkle_proof":"01000000"}}`))
+	}))
+	defer server.Close()
+
+	rpcClient := NewRpcClient(server.URL)
+
+	account, err := rpcClient.GetAccountInfo(AccountByPublicKey(*source), BlockByHeight(0))
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	sourceHash, _ := accountHash(*source)
+	assert.Equal(t, types.AccountHashPrefix+sourceHash, account.AccountHash.ToFormattedString())
+	assert.Equal(t, types.AccessRightReadAddWrite, account.MainPurse.AccessRight)
+	assert.Equal(t, uint64(3), account.ActionThresholds.KeyManagement)
+
+	counter, ok := account.NamedKey("counter")
+	assert.True(t, ok)
+	assert.Equal(t, types.KeyTypeHash, counter.Type)
+	balances, ok := account.NamedKey("balances")
+	assert.True(t, ok)
+	assert.Equal(t, types.KeyTypeURef, balances.Type)
+	_, ok = account.NamedKey("missing")
+	assert.False(t, ok)
+
+	_, err = rpcClient.GetAccountInfo(AccountByHash(sourceHash), BlockByHash("a1b2"))
+	assert.NoError(t, err)
+	_, err = rpcClient.GetAccountInfo(AccountByHash(types.AccountHashPrefix+sourceHash), BlockIdentifier{})
+	assert.NoError(t, err)
+
+	assert.JSONEq(t, `{"public_key":"01d995c93ac47e763433b5ec973cac464c7343d76d6bd47c936cf8ce5d83032061","block_identifier":{"Height":0}}`, requests[0])
+	assert.JSONEq(t, `{"public_key":"account-hash-`+sourceHash+`","block_identifier":{"Hash":"a1b2"}}`, requests[1])
+	assert.JSONEq(t, `{"public_key":"account-hash-`+sourceHash+`","block_identifier":null}`, requests[2])
+}
+
+func TestAccount_Thresholds(t *testing.T) {
+	var jsonAccount JsonAccount
+	assert.NoError(t, json.Unmarshal([]byte(testAccountJSON(t)), &jsonAccount))
+
+	account, err := jsonAccount.ToAccount()
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	assert.Equal(t, uint64(1), account.SignersWeight(*source, *source))
+	assert.False(t, account.CanDeploy(*source))
+	assert.True(t, account.CanDeploy(*dest))
+	assert.False(t, account.CanManageKeys(*dest))
+	assert.True(t, account.CanManageKeys(*source, *dest))
+	assert.False(t, account.CanDeploy())
+
+	jsonAccount.NamedKeys = append(jsonAccount.NamedKeys, NamedKey{Name: "invalid", Key: "unknown-01"})
+	_, err = jsonAccount.ToAccount()
+	assert.Error(t, err)
+}
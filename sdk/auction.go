@@ -0,0 +1,161 @@
+package sdk
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"strings"
+)
+
+func (c *RpcClient) GetAuctionInfo(block BlockIdentifier) (AuctionState, error) {
+	return c.GetAuctionInfoContext(context.Background(), block)
+}
+
+// GetAuctionInfoContext returns the bids and era validators as of the given block, use the zero BlockIdentifier for the latest block
+func (c *RpcClient) GetAuctionInfoContext(ctx context.Context, block BlockIdentifier) (AuctionState, error) {
+	result, err := c.getAuctionInfo(ctx, block)
+	if err != nil {
+		return AuctionState{}, err
+	}
+
+	return result.AuctionState, nil
+}
+
+func (c *RpcClient) GetValidator() (ValidatorResponse, error) {
+	return c.GetValidatorContext(context.Background())
+}
+
+func (c *RpcClient) GetValidatorContext(ctx context.Context) (ValidatorResponse, error) {
+	return c.getAuctionInfo(ctx, BlockIdentifier{})
+}
+
+func (c *RpcClient) getAuctionInfo(ctx context.Context, block BlockIdentifier) (ValidatorResponse, error) {
+	// the block identifier of state_get_auction_info is not optional, the params are left out for the latest block
+	var params interface{}
+	if block.Hash != "" || block.Height != nil {
+		params = blockParams{block}
+	}
+
+	resp, err := c.rpcCall(ctx, "state_get_auction_info", params)
+	if err != nil {
+		return ValidatorResponse{}, err
+	}
+
+	var result ValidatorResponse
+	err = json.Unmarshal(resp.Result, &result)
+	if err != nil {
+		return ValidatorResponse{}, fmt.Errorf("failed to get result: %w", err)
+	}
+
+	return result, nil
+}
+
+type ValidatorResponse struct {
+	Version      string `json:"api_version"`
+	AuctionState `json:"auction_state"`
+}
+
+// Deprecated: ValidatorPesponse is the misspelled former name of ValidatorResponse
+type ValidatorPesponse = ValidatorResponse
+
+type AuctionState struct {
+	StateRootHash string          `json:"state_root_hash"`
+	BlockHeight   uint64          `json:"block_height"`
+	EraValidators []EraValidators `json:"era_validators"`
+	Bids          []Bid           `json:"bids"`
+}
+
+type EraValidators struct {
+	EraId            int               `json:"era_id"`
+	ValidatorWeights []ValidatorWeight `json:"validator_weights"`
+}
+
+type ValidatorWeight struct {
+	PublicKey string `json:"public_key"`
+	Weight    string `json:"weight"`
+}
+
+// Bid is the bid of the validator with the hex encoded PublicKey
+type Bid struct {
+	PublicKey string  `json:"public_key"`
+	Bid       BidInfo `json:"bid"`
+}
+
+type BidInfo struct {
+	BondingPurse   string `json:"bonding_purse"`
+	StakedAmount   BigInt `json:"staked_amount"`
+	DelegationRate uint8  `json:"delegation_rate"`
+	// VestingSchedule is only set for genesis validators
+	VestingSchedule *VestingSchedule `json:"vesting_schedule"`
+	Delegators      []Delegator      `json:"delegators"`
+	// Inactive is set for validators which were evicted or withdrew their bid
+	Inactive bool `json:"inactive"`
+}
+
+type Delegator struct {
+	PublicKey       string           `json:"public_key"`
+	StakedAmount    BigInt           `json:"staked_amount"`
+	BondingPurse    string           `json:"bonding_purse"`
+	Delegatee       string           `json:"delegatee"`
+	VestingSchedule *VestingSchedule `json:"vesting_schedule"`
+}
+
+// VestingSchedule holds the amounts which stay locked after the initial release, LockedAmounts is null before it
+type VestingSchedule struct {
+	InitialReleaseTimestampMillis uint64   `json:"initial_release_timestamp_millis"`
+	LockedAmounts                 []BigInt `json:"locked_amounts"`
+}
+
+// FindBid returns the bid of the validator with the hex encoded public key
+func (s AuctionState) FindBid(validator string) (Bid, bool) {
+	for _, bid := range s.Bids {
+		if strings.EqualFold(bid.PublicKey, validator) {
+			return bid, true
+		}
+	}
+	return Bid{}, false
+}
+
+// DelegatorStake returns the amount the delegator staked with the validator, both given as hex encoded public keys
+func (s AuctionState) DelegatorStake(validator, delegator string) (*big.Int, bool) {
+	bid, ok := s.FindBid(validator)
+	if !ok {
+		return nil, false
+	}
+
+	for _, d := range bid.Bid.Delegators {
+		if strings.EqualFold(d.PublicKey, delegator) {
+			return new(big.Int).Set(&d.StakedAmount.Int), true
+		}
+	}
+	return nil, false
+}
+
+// ValidatorWeight returns the weight of the validator in the era, false if it isn't a validator of the era
+func (s AuctionState) ValidatorWeight(eraId int, validator string) (*big.Int, bool) {
+	for _, era := range s.EraValidators {
+		if era.EraId != eraId {
+			continue
+		}
+
+		for _, weight := range era.ValidatorWeights {
+			if !strings.EqualFold(weight.PublicKey, validator) {
+				continue
+			}
+
+			result, ok := new(big.Int).SetString(weight.Weight, 10)
+			return result, ok
+		}
+	}
+	return nil, false
+}
+
+// TotalStake returns the stake of the validator including the stakes of its delegators
+func (b BidInfo) TotalStake() *big.Int {
+	total := new(big.Int).Set(&b.StakedAmount.Int)
+	for _, delegator := range b.Delegators {
+		total.Add(total, &delegator.StakedAmount.Int)
+	}
+	return total
+}
@@ -0,0 +1,215 @@
+package sdk
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/casper-ecosystem/casper-golang-sdk/keypair"
+	"github.com/casper-ecosystem/casper-golang-sdk/types"
+)
+
+const (
+	AuctionEntryPointDelegate    = "delegate"
+	AuctionEntryPointUndelegate  = "undelegate"
+	AuctionEntryPointRedelegate  = "redelegate"
+	AuctionEntryPointAddBid      = "add_bid"
+	AuctionEntryPointWithdrawBid = "withdraw_bid"
+	AuctionEntryPointActivateBid = "activate_bid"
+)
+
+// AuctionContractName is the name of the auction contract in the system contract registry
+const AuctionContractName = "auction"
+
+// MaxDelegationRate is the highest delegation rate accepted by the auction contract, in percent
+const MaxDelegationRate = 100
+
+var (
+	ErrZeroAmount            = errors.New("amount must be greater than zero")
+	ErrInvalidDelegationRate = errors.New("delegation rate must not exceed 100")
+	ErrSameValidator         = errors.New("new validator must differ from the current validator")
+)
+
+// SystemContractRegistry maps the names of the system contracts to their contract hashes
+type SystemContractRegistry map[string][32]byte
+
+// Auction returns the hash of the auction contract
+func (r SystemContractRegistry) Auction() ([32]byte, bool) {
+	hash, ok := r[AuctionContractName]
+	return hash, ok
+}
+
+func (c *RpcClient) GetSystemContractRegistry(identifier GlobalStateIdentifier) (SystemContractRegistry, error) {
+	return c.GetSystemContractRegistryContext(context.Background(), identifier)
+}
+
+// GetSystemContractRegistryContext returns the hashes of the system contracts, available on nodes since 1.4
+func (c *RpcClient) GetSystemContractRegistryContext(ctx context.Context, identifier GlobalStateIdentifier) (SystemContractRegistry, error) {
+	key := types.Key{Type: types.KeyTypeSystemContractRegistry}
+
+	result, err := c.QueryGlobalStateContext(ctx, identifier, key.ToFormattedString(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if result.StoredValue.CLValue == nil {
+		return nil, errors.New("system contract registry is not a cl value")
+	}
+
+	value, err := result.StoredValue.CLValue.ToCLValue()
+	if err != nil {
+		return nil, fmt.Errorf("invalid system contract registry: %w", err)
+	}
+
+	entries, err := value.AsMap()
+	if err != nil {
+		return nil, fmt.Errorf("invalid system contract registry: %w", err)
+	}
+
+	registry := make(SystemContractRegistry, len(entries))
+	for name, entry := range entries {
+		hash, err := entry.AsBytes()
+		if err != nil {
+			return nil, fmt.Errorf("invalid hash of system contract %q: %w", name, err)
+		}
+		if len(hash) != 32 {
+			return nil, fmt.Errorf("invalid hash of system contract %q: expected 32 bytes, got %d", name, len(hash))
+		}
+
+		var contractHash [32]byte
+		copy(contractHash[:], hash)
+		registry[name] = contractHash
+	}
+
+	return registry, nil
+}
+
+type delegateArgs struct {
+	Delegator keypair.PublicKey `casper:"delegator,public_key"`
+	Validator keypair.PublicKey `casper:"validator,public_key"`
+	Amount    big.Int           `casper:"amount,u512"`
+}
+
+type redelegateArgs struct {
+	Delegator    keypair.PublicKey `casper:"delegator,public_key"`
+	Validator    keypair.PublicKey `casper:"validator,public_key"`
+	Amount       big.Int           `casper:"amount,u512"`
+	NewValidator keypair.PublicKey `casper:"new_validator,public_key"`
+}
+
+type addBidArgs struct {
+	PublicKey      keypair.PublicKey `casper:"public_key,public_key"`
+	DelegationRate uint8             `casper:"delegation_rate,u8"`
+	Amount         big.Int           `casper:"amount,u512"`
+}
+
+type withdrawBidArgs struct {
+	PublicKey keypair.PublicKey `casper:"public_key,public_key"`
+	Amount    big.Int           `casper:"amount,u512"`
+}
+
+type activateBidArgs struct {
+	ValidatorPublicKey keypair.PublicKey `casper:"validator_public_key,public_key"`
+}
+
+// NewDelegateDeploy creates a deploy delegating amount motes of the deploy account to the validator.
+// The deploy account is the delegator, the deploy has to be signed before it is put
+func NewDelegateDeploy(deployParams *DeployParams, auctionHash [32]byte, validator keypair.PublicKey, amount, paymentAmount *big.Int) (*Deploy, error) {
+	if err := checkAmount(amount); err != nil {
+		return nil, err
+	}
+
+	return newAuctionDeploy(deployParams, auctionHash, AuctionEntryPointDelegate, paymentAmount, delegateArgs{
+		Delegator: deployParams.AccountPublicKey,
+		Validator: validator,
+		Amount:    *amount,
+	})
+}
+
+// NewUndelegateDeploy creates a deploy undelegating amount motes of the deploy account from the validator
+func NewUndelegateDeploy(deployParams *DeployParams, auctionHash [32]byte, validator keypair.PublicKey, amount, paymentAmount *big.Int) (*Deploy, error) {
+	if err := checkAmount(amount); err != nil {
+		return nil, err
+	}
+
+	return newAuctionDeploy(deployParams, auctionHash, AuctionEntryPointUndelegate, paymentAmount, delegateArgs{
+		Delegator: deployParams.AccountPublicKey,
+		Validator: validator,
+		Amount:    *amount,
+	})
+}
+
+// NewRedelegateDeploy creates a deploy moving amount motes delegated by the deploy account from the validator to newValidator
+func NewRedelegateDeploy(deployParams *DeployParams, auctionHash [32]byte, validator, newValidator keypair.PublicKey, amount, paymentAmount *big.Int) (*Deploy, error) {
+	if err := checkAmount(amount); err != nil {
+		return nil, err
+	}
+	if validator.Tag == newValidator.Tag && bytes.Equal(validator.PubKeyData, newValidator.PubKeyData) {
+		return nil, ErrSameValidator
+	}
+
+	return newAuctionDeploy(deployParams, auctionHash, AuctionEntryPointRedelegate, paymentAmount, redelegateArgs{
+		Delegator:    deployParams.AccountPublicKey,
+		Validator:    validator,
+		Amount:       *amount,
+		NewValidator: newValidator,
+	})
+}
+
+// NewAddBidDeploy creates a deploy bidding amount motes for the deploy account, delegationRate is the percentage of the delegator rewards kept by the validator
+func NewAddBidDeploy(deployParams *DeployParams, auctionHash [32]byte, delegationRate uint8, amount, paymentAmount *big.Int) (*Deploy, error) {
+	if err := checkAmount(amount); err != nil {
+		return nil, err
+	}
+	if delegationRate > MaxDelegationRate {
+		return nil, fmt.Errorf("%w: got %d", ErrInvalidDelegationRate, delegationRate)
+	}
+
+	return newAuctionDeploy(deployParams, auctionHash, AuctionEntryPointAddBid, paymentAmount, addBidArgs{
+		PublicKey:      deployParams.AccountPublicKey,
+		DelegationRate: delegationRate,
+		Amount:         *amount,
+	})
+}
+
+// NewWithdrawBidDeploy creates a deploy withdrawing amount motes from the bid of the deploy account
+func NewWithdrawBidDeploy(deployParams *DeployParams, auctionHash [32]byte, amount, paymentAmount *big.Int) (*Deploy, error) {
+	if err := checkAmount(amount); err != nil {
+		return nil, err
+	}
+
+	return newAuctionDeploy(deployParams, auctionHash, AuctionEntryPointWithdrawBid, paymentAmount, withdrawBidArgs{
+		PublicKey: deployParams.AccountPublicKey,
+		Amount:    *amount,
+	})
+}
+
+// NewActivateBidDeploy creates a deploy reactivating the inactive bid of the deploy account
+func NewActivateBidDeploy(deployParams *DeployParams, auctionHash [32]byte, paymentAmount *big.Int) (*Deploy, error) {
+	return newAuctionDeploy(deployParams, auctionHash, AuctionEntryPointActivateBid, paymentAmount, activateBidArgs{
+		ValidatorPublicKey: deployParams.AccountPublicKey,
+	})
+}
+
+func newAuctionDeploy(deployParams *DeployParams, auctionHash [32]byte, entryPoint string, paymentAmount *big.Int, args interface{}) (*Deploy, error) {
+	if err := checkAmount(paymentAmount); err != nil {
+		return nil, fmt.Errorf("invalid payment amount: %w", err)
+	}
+
+	runtimeArgs, err := MarshalRuntimeArgs(args)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create %s args: %w", entryPoint, err)
+	}
+
+	session := NewStoredContractByHash(auctionHash, entryPoint, runtimeArgs)
+	return MakeDeploy(deployParams, StandardPayment(paymentAmount), session), nil
+}
+
+func checkAmount(amount *big.Int) error {
+	if amount == nil || amount.Sign() <= 0 {
+		return ErrZeroAmount
+	}
+	return nil
+}
@@ -0,0 +1,107 @@
+package sdk
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/casper-ecosystem/casper-golang-sdk/keypair"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRpcClient_GetSystemContractRegistry(t *testing.T) {
+	registryBytes := "02000000" +
+		"07000000" + hex.EncodeToString([]byte("auction")) + strings.Repeat("2a", 32) +
+		"04000000" + hex.EncodeToString([]byte("mint")) + strings.Repeat("2b", 32)
+
+	var requests []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var request struct {
+			Method string          `json:"method"`
+			Params json.RawMessage `json:"params"`
+		}
+		json.NewDecoder(r.Body).Decode(&request)
+		assert.Equal(t, "query_global_state", request.Method)
+		requests = append(requests, string(request.Params))
+
+		w.Write([]byte(`{"jsonrpc":"2.0","id":"1","result":{"api_version":"1.4.3","block_header":null,"stored_value":{"CLValue":{"cl_type":{"Map":{"key":"String","value":{"ByteArray":32}}},"bytes":"` + registryBytes + `","parsed":null}},"merkle_proof":"01000000"}}`))
+	}))
+	defer server.Close()
+
+	rpcClient := NewRpcClient(server.URL)
+
+	registry, err := rpcClient.GetSystemContractRegistry(GlobalStateByStateRootHash("c0eb"))
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	auction, ok := registry.Auction()
+	assert.True(t, ok)
+	assert.Equal(t, strings.Repeat("2a", 32), hex.EncodeToString(auction[:]))
+	assert.Len(t, registry, 2)
+
+	assert.JSONEq(t, `{"state_identifier":{"StateRootHash":"c0eb"},"key":"system-contract-registry-`+strings.Repeat("00", 32)+`","path":[]}`, requests[0])
+}
+
+func TestAuctionDeploys(t *testing.T) {
+	var auctionHash [32]byte
+	auctionHash[0] = 0x2a
+	amount := big.NewInt(500000000000)
+	payment := big.NewInt(2500000000)
+
+	deployParams := NewDeployParams(*source, "casper-test", nil, 0)
+
+	deploy, err := NewDelegateDeploy(deployParams, auctionHash, *dest, amount, payment)
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.Equal(t, AuctionEntryPointDelegate, deploy.Session.StoredContractByHash.Entrypoint)
+	assert.Equal(t, auctionHash, deploy.Session.StoredContractByHash.Hash)
+	assert.NoError(t, deploy.ValidateDeploy())
+
+	var delegate delegateArgs
+	assert.NoError(t, UnmarshalRuntimeArgs(deploy.Session.StoredContractByHash.Args, &delegate))
+	assert.Equal(t, *source, delegate.Delegator)
+	assert.Equal(t, *dest, delegate.Validator)
+	assert.Equal(t, "500000000000", delegate.Amount.String())
+
+	deploy, err = NewRedelegateDeploy(deployParams, auctionHash, *dest, *source, amount, payment)
+	if !assert.NoError(t, err) {
+		return
+	}
+	var redelegate redelegateArgs
+	assert.NoError(t, UnmarshalRuntimeArgs(deploy.Session.StoredContractByHash.Args, &redelegate))
+	assert.Equal(t, *source, redelegate.NewValidator)
+	assert.Equal(t, []string{"delegator", "validator", "amount", "new_validator"}, deploy.Session.StoredContractByHash.Args.KeyOrder)
+
+	deploy, err = NewAddBidDeploy(deployParams, auctionHash, 10, amount, payment)
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.Equal(t, AuctionEntryPointAddBid, deploy.Session.StoredContractByHash.Entrypoint)
+	assert.Equal(t, "0a", deploy.Session.StoredContractByHash.Args.Args["delegation_rate"].StringBytes)
+
+	deploy, err = NewActivateBidDeploy(deployParams, auctionHash, payment)
+	if !assert.NoError(t, err) {
+		return
+	}
+	var activate activateBidArgs
+	assert.NoError(t, UnmarshalRuntimeArgs(deploy.Session.StoredContractByHash.Args, &activate))
+	assert.Equal(t, *source, activate.ValidatorPublicKey)
+
+	_, err = NewUndelegateDeploy(deployParams, auctionHash, *dest, big.NewInt(0), payment)
+	assert.True(t, errors.Is(err, ErrZeroAmount))
+	_, err = NewWithdrawBidDeploy(deployParams, auctionHash, nil, payment)
+	assert.True(t, errors.Is(err, ErrZeroAmount))
+	_, err = NewWithdrawBidDeploy(deployParams, auctionHash, amount, big.NewInt(-1))
+	assert.True(t, errors.Is(err, ErrZeroAmount))
+	_, err = NewAddBidDeploy(deployParams, auctionHash, 101, amount, payment)
+	assert.True(t, errors.Is(err, ErrInvalidDelegationRate))
+	_, err = NewRedelegateDeploy(deployParams, auctionHash, *dest, keypair.PublicKey{Tag: dest.Tag, PubKeyData: dest.PubKeyData}, amount, payment)
+	assert.True(t, errors.Is(err, ErrSameValidator))
+}
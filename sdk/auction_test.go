@@ -0,0 +1,115 @@
+package sdk
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const testValidator = "01197f6b23e16c8532c6abc838facd5ea789be0c76b2920334039bfa8b3d368d61"
+const testDelegator = "0203791c1a7414511e9b6a05b83647c5d9ccffb2c6b556454eabd00d540faac64295"
+
+const testAuctionInfo = `{"jsonrpc":"2.0","id":"1","result":{"api_version":"1.4.3","auction_state":{
+	"state_root_hash":"c0eb76e0c3c7a928a0cb43e82eb4fad683d9ad626bcd3b7835a466c0587b0fff",
+	"block_height":265834,
+	"era_validators":[{"era_id":2890,"validator_weights":[{"public_key":"` + testValidator + `","weight":"1500000000000000000"}]}],
+	"bids":[
+		{"public_key":"` + testValidator + `","bid":{
+			"bonding_purse":"uref-2c2c2c2c2c2c2c2c2c2c2c2c2c2c2c2c2c2c2c2c2c2c2c2c2c2c2c2c2c2c2c2c-007",
+			"staked_amount":"1000000000000000000",
+			"delegation_rate":10,
+			"vesting_schedule":{"initial_release_timestamp_millis":1616000000000,"locked_amounts":null},
+			"delegators":[{
+				"public_key":"` + testDelegator + `",
+				"staked_amount":"500000000000000000",
+				"bonding_purse":"uref-2d2d2d2d2d2d2d2d2d2d2d2d2d2d2d2d2d2d2d2d2d2d2d2d2d2d2d2d2d2d2d2d-007",
+				"delegatee":"` + testValidator + `",
+				"vesting_schedule":null
+			}],
+			"inactive":false
+		}},
+		{"public_key":"0203791c1a7414511e9b6a05b83647c5d9ccffb2c6b556454eabd00d540faac64200","bid":{
+			"bonding_purse":"uref-2e2e2e2e2e2e2e2e2e2e2e2e2e2e2e2e2e2e2e2e2e2e2e2e2e2e2e2e2e2e2e2e-007",
+			"staked_amount":"0",
+			"delegation_rate":100,
+			"vesting_schedule":null,
+			"delegators":[],
+			"inactive":true
+		}}
+	]
+}}}`
+
+func TestRpcClient_GetAuctionInfo(t *testing.T) {
+	var requests []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var request struct {
+			Method string          `json:"method"`
+			Params json.RawMessage `json:"params"`
+		}
+		json.NewDecoder(r.Body).Decode(&request)
+		assert.Equal(t, "state_get_auction_info", request.Method)
+		requests = append(requests, string(request.Params))
+
+		w.Write([]byte(testAuctionInfo))
+	}))
+	defer server.Close()
+
+	rpcClient := NewRpcClient(server.URL)
+
+	state, err := rpcClient.GetAuctionInfo(BlockByHeight(265834))
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	assert.Equal(t, uint64(265834), state.BlockHeight)
+	assert.Len(t, state.Bids, 2)
+
+	bid, ok := state.FindBid(testValidator)
+	assert.True(t, ok)
+	assert.Equal(t, uint8(10), bid.Bid.DelegationRate)
+	assert.Equal(t, uint64(1616000000000), bid.Bid.VestingSchedule.InitialReleaseTimestampMillis)
+	assert.Nil(t, bid.Bid.VestingSchedule.LockedAmounts)
+	assert.Equal(t, "1500000000000000000", bid.Bid.TotalStake().String())
+
+	inactive, ok := state.FindBid("0203791c1a7414511e9b6a05b83647c5d9ccffb2c6b556454eabd00d540faac64200")
+	assert.True(t, ok)
+	assert.True(t, inactive.Bid.Inactive)
+
+	stake, ok := state.DelegatorStake(testValidator, testDelegator)
+	assert.True(t, ok)
+	assert.Equal(t, "500000000000000000", stake.String())
+	_, ok = state.DelegatorStake(testValidator, testValidator)
+	assert.False(t, ok)
+
+	weight, ok := state.ValidatorWeight(2890, testValidator)
+	assert.True(t, ok)
+	assert.Equal(t, "1500000000000000000", weight.String())
+	_, ok = state.ValidatorWeight(2891, testValidator)
+	assert.False(t, ok)
+
+	validator, err := rpcClient.GetValidator()
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.Equal(t, "1.4.3", validator.Version)
+	assert.Equal(t, 2890, validator.EraValidators[0].EraId)
+
+	assert.JSONEq(t, `{"block_identifier":{"Height":265834}}`, requests[0])
+	assert.Equal(t, "null", requests[1])
+}
+
+func TestBigInt_JSON(t *testing.T) {
+	var amount BigInt
+	assert.NoError(t, json.Unmarshal([]byte(`"123456789012345678901234567890"`), &amount))
+	assert.Equal(t, "123456789012345678901234567890", amount.String())
+
+	data, err := json.Marshal(amount)
+	assert.NoError(t, err)
+	assert.Equal(t, `"123456789012345678901234567890"`, string(data))
+
+	assert.Error(t, json.Unmarshal([]byte(`"12a"`), &amount))
+	assert.Error(t, json.Unmarshal([]byte(`12`), &amount))
+}
@@ -0,0 +1,252 @@
+package sdk
+
+import (
+	"bytes"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/casper-ecosystem/casper-golang-sdk/serialization"
+	"golang.org/x/crypto/blake2b"
+)
+
+var (
+	ErrInvalidBlockHash     = errors.New("invalid block hash")
+	ErrInvalidBlockBodyHash = errors.New("invalid block body hash")
+)
+
+// VerifyBlock recomputes the hashes of the header and the body and checks them against Hash and the body hash of the header
+func (b BlockResponse) VerifyBlock() error {
+	serializedHeader, err := b.Header.ToBytes()
+	if err != nil {
+		return fmt.Errorf("invalid block header: %w", err)
+	}
+
+	blockHash := blake2b.Sum256(serializedHeader)
+	if !strings.EqualFold(b.Hash, hex.EncodeToString(blockHash[:])) {
+		return fmt.Errorf("%w: expected %s, got %s", ErrInvalidBlockHash, hex.EncodeToString(blockHash[:]), b.Hash)
+	}
+
+	serializedBody, err := b.Body.ToBytes()
+	if err != nil {
+		return fmt.Errorf("invalid block body: %w", err)
+	}
+
+	bodyHash := blake2b.Sum256(serializedBody)
+	if !strings.EqualFold(b.Header.BodyHash, hex.EncodeToString(bodyHash[:])) {
+		return fmt.Errorf("%w: expected %s, got %s", ErrInvalidBlockBodyHash, hex.EncodeToString(bodyHash[:]), b.Header.BodyHash)
+	}
+
+	return nil
+}
+
+// ToBytes serializes the header the way the node does to compute the block hash
+func (h BlockHeader) ToBytes() ([]byte, error) {
+	var buf bytes.Buffer
+	enc := serialization.NewEncoder(&buf)
+
+	for _, hash := range []struct {
+		name  string
+		value string
+	}{
+		{"parent hash", h.ParentHash},
+		{"state root hash", h.StateRootHash},
+		{"body hash", h.BodyHash},
+	} {
+		if err := encodeHash(enc, hash.name, hash.value); err != nil {
+			return nil, err
+		}
+	}
+
+	if _, err := enc.EncodeBool(h.RandomBit); err != nil {
+		return nil, err
+	}
+
+	if err := encodeHash(enc, "accumulated seed", h.AccumulatedSeed); err != nil {
+		return nil, err
+	}
+
+	if _, err := enc.EncodeBool(h.EraEnd != nil); err != nil {
+		return nil, err
+	}
+	if h.EraEnd != nil {
+		eraEnd, err := h.EraEnd.ToBytes()
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(eraEnd)
+	}
+
+	if _, err := enc.EncodeUInt64(uint64(h.Timestamp.UnixNano() / 1000000)); err != nil {
+		return nil, err
+	}
+	if _, err := enc.EncodeUInt64(h.EraID); err != nil {
+		return nil, err
+	}
+	if _, err := enc.EncodeUInt64(h.Height); err != nil {
+		return nil, err
+	}
+
+	version := strings.Split(h.ProtocolVersion, ".")
+	if len(version) != 3 {
+		return nil, fmt.Errorf("invalid protocol version %q", h.ProtocolVersion)
+	}
+	for _, part := range version {
+		number, err := strconv.ParseUint(part, 10, 32)
+		if err != nil {
+			return nil, fmt.Errorf("invalid protocol version %q: %w", h.ProtocolVersion, err)
+		}
+		if _, err := enc.EncodeUInt32(uint32(number)); err != nil {
+			return nil, err
+		}
+	}
+
+	return buf.Bytes(), nil
+}
+
+// ToBytes serializes the era end, rewards and validator weights are sorted by public key like the maps of the node
+func (e EraEnd) ToBytes() ([]byte, error) {
+	var buf bytes.Buffer
+	enc := serialization.NewEncoder(&buf)
+
+	if err := encodePublicKeys(enc, "equivocator", e.EraReport.Equivocators); err != nil {
+		return nil, err
+	}
+
+	rewards := make(map[string][]byte, len(e.EraReport.Rewards))
+	for _, reward := range e.EraReport.Rewards {
+		amount, err := serialization.Marshal(reward.Amount)
+		if err != nil {
+			return nil, err
+		}
+		rewards[reward.Validator] = amount
+	}
+	if err := encodePublicKeyMap(enc, "rewarded validator", rewards); err != nil {
+		return nil, err
+	}
+
+	if err := encodePublicKeys(enc, "inactive validator", e.EraReport.InactiveValidators); err != nil {
+		return nil, err
+	}
+
+	weights := make(map[string][]byte, len(e.NextEraValidatorWeights))
+	for _, weight := range e.NextEraValidatorWeights {
+		amount, err := serialization.Marshal(serialization.U512{Int: weight.Weight.Int})
+		if err != nil {
+			return nil, err
+		}
+		weights[weight.Validator] = amount
+	}
+	if err := encodePublicKeyMap(enc, "next era validator", weights); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+// ToBytes serializes the body the way the node does to compute the body hash
+func (b BlockBody) ToBytes() ([]byte, error) {
+	var buf bytes.Buffer
+	enc := serialization.NewEncoder(&buf)
+
+	if err := encodePublicKey(enc, "proposer", b.Proposer); err != nil {
+		return nil, err
+	}
+
+	for _, hashes := range []struct {
+		name   string
+		values []string
+	}{
+		{"deploy hash", b.DeployHashes},
+		{"transfer hash", b.TransferHashes},
+	} {
+		if _, err := enc.EncodeUInt32(uint32(len(hashes.values))); err != nil {
+			return nil, err
+		}
+		for _, hash := range hashes.values {
+			if err := encodeHash(enc, hashes.name, hash); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	return buf.Bytes(), nil
+}
+
+// encodeHash writes the 32 bytes of the hex encoded hash
+func encodeHash(enc *serialization.Encoder, name, value string) error {
+	hash, err := hex.DecodeString(value)
+	if err != nil {
+		return fmt.Errorf("invalid %s: %w", name, err)
+	}
+	if len(hash) != 32 {
+		return fmt.Errorf("invalid %s: expected 32 bytes, got %d", name, len(hash))
+	}
+
+	_, err = enc.EncodeFixedByteArray(hash)
+	return err
+}
+
+// encodePublicKeys writes the list of hex encoded public keys
+func encodePublicKeys(enc *serialization.Encoder, name string, publicKeys []string) error {
+	if _, err := enc.EncodeUInt32(uint32(len(publicKeys))); err != nil {
+		return err
+	}
+
+	for _, publicKey := range publicKeys {
+		if err := encodePublicKey(enc, name, publicKey); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// encodePublicKey writes the hex encoded public key, its hex form is the serialized form
+func encodePublicKey(enc *serialization.Encoder, name, publicKey string) error {
+	key, err := hex.DecodeString(publicKey)
+	if err != nil || len(key) == 0 {
+		return fmt.Errorf("invalid %s %q", name, publicKey)
+	}
+
+	_, err = enc.EncodeFixedByteArray(key)
+	return err
+}
+
+// encodePublicKeyMap writes the serialized values by hex encoded public key, in the order of the serialized keys
+func encodePublicKeyMap(enc *serialization.Encoder, name string, values map[string][]byte) error {
+	type entry struct {
+		key   []byte
+		value []byte
+	}
+
+	entries := make([]entry, 0, len(values))
+	for publicKey, value := range values {
+		key, err := hex.DecodeString(publicKey)
+		if err != nil || len(key) == 0 {
+			return fmt.Errorf("invalid %s %q", name, publicKey)
+		}
+		entries = append(entries, entry{key, value})
+	}
+
+	sort.Slice(entries, func(i, j int) bool {
+		return bytes.Compare(entries[i].key, entries[j].key) < 0
+	})
+
+	if _, err := enc.EncodeUInt32(uint32(len(entries))); err != nil {
+		return err
+	}
+	for _, e := range entries {
+		if _, err := enc.EncodeFixedByteArray(e.key); err != nil {
+			return err
+		}
+		if _, err := enc.EncodeFixedByteArray(e.value); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,108 @@
+package sdk
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/crypto/blake2b"
+)
+
+const testSwitchBlockHeader = `{
+	"parent_hash":"` + testParentHash + `",
+	"state_root_hash":"` + testStateRootHash + `",
+	"body_hash":"%s",
+	"random_bit":true,
+	"accumulated_seed":"` + testAccumulatedSeed + `",
+	"era_end":{
+		"era_report":{
+			"equivocators":[],
+			"rewards":[{"validator":"` + testDelegator + `","amount":7},{"validator":"` + testValidator + `","amount":1000}],
+			"inactive_validators":["` + testValidator + `"]
+		},
+		"next_era_validator_weights":[{"validator":"` + testValidator + `","weight":"256"}]
+	},
+	"timestamp":"2021-11-10T10:00:00.000Z",
+	"era_id":2890,
+	"height":265834,
+	"protocol_version":"1.4.3"
+}`
+
+const (
+	testParentHash      = "0a0a0a0a0a0a0a0a0a0a0a0a0a0a0a0a0a0a0a0a0a0a0a0a0a0a0a0a0a0a0a0a"
+	testStateRootHash   = "0b0b0b0b0b0b0b0b0b0b0b0b0b0b0b0b0b0b0b0b0b0b0b0b0b0b0b0b0b0b0b0b"
+	testAccumulatedSeed = "0c0c0c0c0c0c0c0c0c0c0c0c0c0c0c0c0c0c0c0c0c0c0c0c0c0c0c0c0c0c0c0c"
+)
+
+func TestBlockHeader_ToBytes(t *testing.T) {
+	var header BlockHeader
+	err := json.Unmarshal([]byte(strings.Replace(testSwitchBlockHeader, "%s", strings.Repeat("0d", 32), 1)), &header)
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.Equal(t, uint64(2890), header.EraID)
+	assert.Equal(t, uint64(265834), header.Height)
+
+	serialized, err := header.ToBytes()
+	assert.NoError(t, err)
+
+	expected := testParentHash + testStateRootHash + strings.Repeat("0d", 32) + "01" + testAccumulatedSeed +
+		// era end with no equivocators, the rewards sorted by public key, one inactive validator and one weight
+		"01" + "00000000" +
+		"02000000" + testValidator + "e803000000000000" + testDelegator + "0700000000000000" +
+		"01000000" + testValidator +
+		"01000000" + testValidator + "020001" +
+		// 2021-11-10T10:00:00Z in milliseconds, the era, the height and the protocol version
+		"003d4a097d010000" + "4a0b000000000000" + "6a0e040000000000" + "010000000400000003000000"
+	assert.Equal(t, expected, hex.EncodeToString(serialized))
+
+	header.EraEnd = nil
+	serialized, err = header.ToBytes()
+	assert.NoError(t, err)
+	assert.Equal(t, "00"+"003d4a097d010000", hex.EncodeToString(serialized[129:138]))
+
+	header.ProtocolVersion = "1.4"
+	_, err = header.ToBytes()
+	assert.Error(t, err)
+}
+
+func TestBlockResponse_VerifyBlock(t *testing.T) {
+	body := BlockBody{
+		Proposer:       testValidator,
+		DeployHashes:   []string{strings.Repeat("1a", 32)},
+		TransferHashes: []string{},
+	}
+	serializedBody, err := body.ToBytes()
+	assert.NoError(t, err)
+	assert.Equal(t, testValidator+"01000000"+strings.Repeat("1a", 32)+"00000000", hex.EncodeToString(serializedBody))
+	bodyHash := blake2b.Sum256(serializedBody)
+
+	var block BlockResponse
+	block.Body = body
+	err = json.Unmarshal([]byte(strings.Replace(testSwitchBlockHeader, "%s", hex.EncodeToString(bodyHash[:]), 1)), &block.Header)
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	serializedHeader, err := block.Header.ToBytes()
+	assert.NoError(t, err)
+	blockHash := blake2b.Sum256(serializedHeader)
+	block.Hash = hex.EncodeToString(blockHash[:])
+
+	assert.NoError(t, block.VerifyBlock())
+
+	tampered := block
+	tampered.Header.Height++
+	assert.True(t, errors.Is(tampered.VerifyBlock(), ErrInvalidBlockHash))
+
+	tampered = block
+	tampered.Body.TransferHashes = []string{strings.Repeat("1b", 32)}
+	assert.True(t, errors.Is(tampered.VerifyBlock(), ErrInvalidBlockBodyHash))
+
+	tampered = block
+	tampered.Body.Proposer = "xyz"
+	assert.Error(t, tampered.VerifyBlock())
+}
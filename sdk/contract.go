@@ -4,6 +4,7 @@ import (
 	"encoding/hex"
 	"io/ioutil"
 	"math/big"
+	"strings"
 
 	"github.com/casper-ecosystem/casper-golang-sdk/keypair"
 	"github.com/casper-ecosystem/casper-golang-sdk/serialization"
@@ -65,76 +66,51 @@ func (b BoundContract) Deploy(args RuntimeArgs, paymentAmount big.Int, chainName
 
 type FaucetContract struct{}
 
+type faucetArgs struct {
+	Account types.Key `casper:"account,key"`
+}
+
 func (f FaucetContract) MakeArgs(accountHash string) RuntimeArgs {
-	decodedHash, err := hex.DecodeString(accountHash)
-	if err != nil || len(decodedHash) != 32 {
+	key, ok := accountKey(accountHash)
+	if !ok {
 		return RuntimeArgs{}
 	}
 
-	key := types.Key{
-		Type:    types.KeyTypeAccount,
-		Account: [32]byte{},
-	}
-
-	for i := 0; i < 32; i++ {
-		key.Account[i] = decodedHash[i]
-	}
-
-	marshaledKey, err := serialization.Marshal(types.CLValue{Type: types.CLTypeKey, Key: &key})
-
+	args, err := MarshalRuntimeArgs(faucetArgs{Account: key})
 	if err != nil {
 		return RuntimeArgs{}
 	}
 
-	return RuntimeArgs{
-		Args: map[string]Value{"account": {
-			Tag:         types.CLTypeKey,
-			StringBytes: hex.EncodeToString(marshaledKey),
-		}},
-		KeyOrder: []string{"account"},
-	}
+	return args
 }
 
 type TransferContract struct{}
 
+type transferArgs struct {
+	Account types.Key `casper:"account,key"`
+	Amount  big.Int   `casper:"amount,u512"`
+}
+
 func (t TransferContract) MakeArgs(accountHash string, amount big.Int) RuntimeArgs {
-	decodedHash, err := hex.DecodeString(accountHash)
-	if err != nil || len(decodedHash) != 32 {
+	key, ok := accountKey(accountHash)
+	if !ok {
 		return RuntimeArgs{}
 	}
 
-	key := types.Key{
-		Type:    types.KeyTypeAccount,
-		Account: [32]byte{},
-	}
-
-	for i := 0; i < 32; i++ {
-		key.Account[i] = decodedHash[i]
-	}
-
-	marshaledKey, err := serialization.Marshal(types.CLValue{Type: types.CLTypeKey, Key: &key})
-
+	args, err := MarshalRuntimeArgs(transferArgs{Account: key, Amount: amount})
 	if err != nil {
 		return RuntimeArgs{}
 	}
 
-	marshaledAmount, err := serialization.Marshal(types.CLValue{Type: types.CLTypeU512, U512: &amount})
+	return args
+}
 
+// accountKey returns the Account key of the account hash, given in hex or as formatted account-hash-<hex> string
+func accountKey(accountHash string) (types.Key, bool) {
+	key, err := types.KeyFromFormattedString(types.AccountHashPrefix + strings.TrimPrefix(accountHash, types.AccountHashPrefix))
 	if err != nil {
-		return RuntimeArgs{}
+		return types.Key{}, false
 	}
 
-	return RuntimeArgs{
-		Args: map[string]Value{
-			"account": {
-				Tag:         types.CLTypeKey,
-				StringBytes: hex.EncodeToString(marshaledKey),
-			},
-			"amount": {
-				Tag:         types.CLTypeU512,
-				StringBytes: hex.EncodeToString(marshaledAmount),
-			},
-		},
-		KeyOrder: []string{"account", "amount"},
-	}
+	return *key, true
 }
@@ -1,15 +1,19 @@
 package sdk
 
 import (
+	"bytes"
 	"encoding/hex"
 	"encoding/json"
 	"errors"
+	"fmt"
+	"math"
 	"math/big"
 	"strconv"
 	"time"
 
 	"github.com/casper-ecosystem/casper-golang-sdk/keypair"
 	"github.com/casper-ecosystem/casper-golang-sdk/keypair/ed25519"
+	"github.com/casper-ecosystem/casper-golang-sdk/keypair/secp256k1"
 	"github.com/casper-ecosystem/casper-golang-sdk/serialization"
 	"github.com/casper-ecosystem/casper-golang-sdk/types"
 	"golang.org/x/crypto/blake2b"
@@ -44,28 +48,53 @@ func (d *Deploy) IsTransfer() bool {
 	return false
 }
 
-func (d *Deploy) ValidateDeploy() bool {
+var (
+	ErrInvalidBodyHash          = errors.New("invalid body hash")
+	ErrInvalidDeployHash        = errors.New("invalid deploy hash")
+	ErrDuplicateApproval        = errors.New("duplicate approval signer")
+	ErrInvalidApprovalSignature = errors.New("invalid approval signature")
+)
+
+// ValidateDeploy checks the body and deploy hashes and verifies the signatures of all approvals
+func (d *Deploy) ValidateDeploy() error {
+	if d.Header == nil || d.Payment == nil || d.Session == nil {
+		return errors.New("invalid deploy: missing header, payment or session")
+	}
+
 	serializedBody := SerializeBody(d.Payment, d.Session)
 	bodyHash := blake2b.Sum256(serializedBody)
 
-	for i := range bodyHash {
-		if d.Header.BodyHash[i] != bodyHash[i] {
-			return false
-		}
+	if !bytes.Equal(d.Header.BodyHash, bodyHash[:]) {
+		return fmt.Errorf("%w: expected %s, got %s", ErrInvalidBodyHash, hex.EncodeToString(bodyHash[:]), hex.EncodeToString(d.Header.BodyHash))
 	}
 
 	serializedHeader := SerializeHeader(d.Header)
 	deployHash := blake2b.Sum256(serializedHeader)
 
-	for i := range deployHash {
-		if d.Hash[i] != deployHash[i] {
-			return false
-		}
+	if !bytes.Equal(d.Hash, deployHash[:]) {
+		return fmt.Errorf("%w: expected %s, got %s", ErrInvalidDeployHash, hex.EncodeToString(deployHash[:]), hex.EncodeToString(d.Hash))
 	}
 
-	// TODO: Verify included signatures
+	signers := make(map[string]bool, len(d.Approvals))
 
-	return true
+	for i, approval := range d.Approvals {
+		signer, err := approval.Signer.ToBytes()
+		if err != nil {
+			return fmt.Errorf("invalid signer of approval %d: %w", i, err)
+		}
+
+		signerHex := hex.EncodeToString(signer)
+		if signers[signerHex] {
+			return fmt.Errorf("%w: %s", ErrDuplicateApproval, signerHex)
+		}
+		signers[signerHex] = true
+
+		if !approval.Signer.Verify(d.Hash, approval.Signature) {
+			return fmt.Errorf("%w: approval %d by %s", ErrInvalidApprovalSignature, i, signerHex)
+		}
+	}
+
+	return nil
 }
 
 func (d *Deploy) SignDeploy(keys keypair.KeyPair) {
@@ -152,6 +181,55 @@ func NewDeployHeader(account keypair.PublicKey, timeStamp int64, ttl int64, gasP
 	return d
 }
 
+// deployHeaderJSON is the json form of DeployHeader, the dependencies are hex encoded deploy hashes
+type deployHeaderJSON struct {
+	Account      keypair.PublicKey `json:"account"`
+	Timestamp    Timestamp         `json:"timestamp"`
+	TTL          Duration          `json:"ttl"`
+	GasPrice     uint64            `json:"gas_price"`
+	BodyHash     Hash              `json:"body_hash"`
+	Dependencies []Hash            `json:"dependencies"`
+	ChainName    string            `json:"chain_name"`
+}
+
+func (d DeployHeader) MarshalJSON() ([]byte, error) {
+	dependencies := make([]Hash, len(d.Dependencies))
+	for i, dependency := range d.Dependencies {
+		dependencies[i] = dependency
+	}
+
+	return json.Marshal(deployHeaderJSON{
+		Account:      d.Account,
+		Timestamp:    d.Timestamp,
+		TTL:          d.TTL,
+		GasPrice:     d.GasPrice,
+		BodyHash:     d.BodyHash,
+		Dependencies: dependencies,
+		ChainName:    d.ChainName,
+	})
+}
+
+func (d *DeployHeader) UnmarshalJSON(data []byte) error {
+	var header deployHeaderJSON
+	if err := json.Unmarshal(data, &header); err != nil {
+		return err
+	}
+
+	*d = DeployHeader{
+		Account:      header.Account,
+		Timestamp:    header.Timestamp,
+		TTL:          header.TTL,
+		GasPrice:     header.GasPrice,
+		BodyHash:     header.BodyHash,
+		Dependencies: make([][]byte, len(header.Dependencies)),
+		ChainName:    header.ChainName,
+	}
+	for i, dependency := range header.Dependencies {
+		d.Dependencies[i] = dependency
+	}
+	return nil
+}
+
 func (d DeployHeader) ToBytes() []uint8 {
 	acc, err := d.Account.ToBytes()
 	if err != nil {
@@ -179,11 +257,15 @@ func (d DeployHeader) ToBytes() []uint8 {
 
 	result = append(result, d.BodyHash...)
 
-	dependencies, err := serialization.Marshal(d.Dependencies)
+	// the dependencies are a u32 count followed by each deploy hash as 32 fixed bytes, without a length prefix per hash
+	dependencies, err := serialization.Marshal(uint32(len(d.Dependencies)))
 	if err != nil {
 		return nil
 	}
 	result = append(result, dependencies...)
+	for _, dependency := range d.Dependencies {
+		result = append(result, dependency...)
+	}
 
 	chainN, err := serialization.Marshal(d.ChainName)
 	if err != nil {
@@ -333,23 +415,11 @@ func (e *ExecutableDeployItem) IsTransfer() bool {
 }
 
 func (e *ExecutableDeployItem) SetArg(key string, value types.CLValue) error {
-
-	marshaledValue, err := serialization.Marshal(value)
+	valueToAdd, err := NewValue(value)
 	if err != nil {
 		return err
 	}
 
-	valueToAdd := Value{
-		Tag: value.Type,
-	}
-
-	if value.Type == types.CLTypeOption {
-		valueToAdd.IsOptional = true
-		valueToAdd.Optional = &Value{Tag: value.Option.Type, StringBytes: hex.EncodeToString(marshaledValue)}
-	} else {
-		valueToAdd.StringBytes = hex.EncodeToString(marshaledValue)
-	}
-
 	switch e.Type {
 	case ExecutableDeployItemTypeModuleBytes:
 		e.ModuleBytes.Args.Insert(key, valueToAdd)
@@ -408,8 +478,10 @@ func (e ExecutableDeployItem) MarshalJSON() ([]byte, error) {
 func (e *ExecutableDeployItem) UnmarshalJSON(data []byte) error {
 	var tempMap map[string]interface{}
 
-	err := json.Unmarshal(data, &tempMap)
-	if err != nil {
+	// numbers are kept as written, so the parsed values of u64 args don't lose precision
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	if err := decoder.Decode(&tempMap); err != nil {
 		return err
 	}
 
@@ -505,11 +577,12 @@ func (m ModuleBytes) ToBytes() []byte {
 }
 
 func (m ModuleBytes) MarshalJSON() ([]byte, error) {
+	// the fields are written in the order of casper-client
 	data := map[string]interface{}{
-		"ModuleBytes": map[string]interface{}{
-			"module_bytes": hex.EncodeToString(m.ModuleBytes),
-			"args":         m.Args.ToJSONInterface(),
-		},
+		"ModuleBytes": struct {
+			ModuleBytes string        `json:"module_bytes"`
+			Args        []interface{} `json:"args"`
+		}{hex.EncodeToString(m.ModuleBytes), m.Args.ToJSONInterface()},
 	}
 	return json.Marshal(data)
 }
@@ -518,7 +591,11 @@ func (m *ModuleBytes) UnmarshalModuleBytes(tempMap map[string]interface{}) error
 	if tempMap["module_bytes"] == nil {
 		m.ModuleBytes = make([]byte, 0)
 	} else {
-		m.ModuleBytes = []byte(tempMap["module_bytes"].(string))
+		moduleBytes, err := hex.DecodeString(tempMap["module_bytes"].(string))
+		if err != nil {
+			return fmt.Errorf("invalid module_bytes: %w", err)
+		}
+		m.ModuleBytes = moduleBytes
 	}
 
 	if tempMap["args"] == nil {
@@ -572,11 +649,11 @@ func (s StoredContractByHash) ToBytes() []byte {
 
 func (s StoredContractByHash) MarshalJSON() ([]byte, error) {
 	data := map[string]interface{}{
-		"StoredContractByHash": map[string]interface{}{
-			"hash":        hex.EncodeToString(s.Hash[:]),
-			"entry_point": s.Entrypoint,
-			"args":        s.Args.ToJSONInterface(),
-		},
+		"StoredContractByHash": struct {
+			Hash       string        `json:"hash"`
+			EntryPoint string        `json:"entry_point"`
+			Args       []interface{} `json:"args"`
+		}{hex.EncodeToString(s.Hash[:]), s.Entrypoint, s.Args.ToJSONInterface()},
 	}
 	return json.Marshal(data)
 }
@@ -659,11 +736,11 @@ func (s StoredContractByName) ToBytes() []byte {
 
 func (s StoredContractByName) MarshalJSON() ([]byte, error) {
 	data := map[string]interface{}{
-		"StoredContractByName": map[string]interface{}{
-			"name":        s.Name,
-			"entry_point": s.Entrypoint,
-			"args":        s.Args.ToJSONInterface(),
-		},
+		"StoredContractByName": struct {
+			Name       string        `json:"name"`
+			EntryPoint string        `json:"entry_point"`
+			Args       []interface{} `json:"args"`
+		}{s.Name, s.Entrypoint, s.Args.ToJSONInterface()},
 	}
 	return json.Marshal(data)
 }
@@ -753,20 +830,13 @@ func (s StoredVersionedContractByHash) ToBytes() []byte {
 }
 
 func (s StoredVersionedContractByHash) MarshalJSON() ([]byte, error) {
-	var version string
-	if s.Version.Option != nil {
-		version = strconv.Itoa(int(*s.Version.Option.U32))
-	} else {
-		version = "None"
-	}
-
 	data := map[string]interface{}{
-		"StoredVersionedContractByHash": map[string]interface{}{
-			"hash":        hex.EncodeToString(s.Hash[:]),
-			"entry_point": s.Entrypoint,
-			"args":        s.Args.ToJSONInterface(),
-			"version":     version,
-		},
+		"StoredVersionedContractByHash": struct {
+			Hash       string        `json:"hash"`
+			Version    *uint32       `json:"version"`
+			EntryPoint string        `json:"entry_point"`
+			Args       []interface{} `json:"args"`
+		}{hex.EncodeToString(s.Hash[:]), versionToJSON(s.Version), s.Entrypoint, s.Args.ToJSONInterface()},
 	}
 
 	return json.Marshal(data)
@@ -789,17 +859,9 @@ func (s *StoredVersionedContractByHash) UnmarshalStoredVersionedContractByHash(t
 		s.Hash[i] = decodedHash[i]
 	}
 
-	version, ok := tempMap["version"].(string)
-
-	if version == "None" {
-		s.Version = &types.CLValue{Type: types.CLTypeOption, Option: nil}
-	} else {
-		parsedVersion, err := strconv.ParseInt(version, 10, 32)
-		if err != nil {
-			return err
-		}
-		parsedVersion32 := uint32(parsedVersion)
-		s.Version = &types.CLValue{Type: types.CLTypeOption, Option: &types.CLValue{Type: types.CLTypeU32, U32: &parsedVersion32}}
+	s.Version, err = parseVersionJSON(tempMap["version"])
+	if err != nil {
+		return err
 	}
 
 	s.Entrypoint, ok = tempMap["entry_point"].(string)
@@ -883,20 +945,13 @@ func (s StoredVersionedContractByName) ToBytes() []byte {
 }
 
 func (s StoredVersionedContractByName) MarshalJSON() ([]byte, error) {
-	var version string
-	if s.Version.Option != nil {
-		version = strconv.Itoa(int(*s.Version.Option.U32))
-	} else {
-		version = "None"
-	}
-
 	data := map[string]interface{}{
-		"StoredVersionedContractByName": map[string]interface{}{
-			"name":        s.Name,
-			"entry_point": s.Entrypoint,
-			"args":        s.Args.ToJSONInterface(),
-			"version":     version,
-		},
+		"StoredVersionedContractByName": struct {
+			Name       string        `json:"name"`
+			Version    *uint32       `json:"version"`
+			EntryPoint string        `json:"entry_point"`
+			Args       []interface{} `json:"args"`
+		}{s.Name, versionToJSON(s.Version), s.Entrypoint, s.Args.ToJSONInterface()},
 	}
 	return json.Marshal(data)
 }
@@ -915,17 +970,10 @@ func (s *StoredVersionedContractByName) UnmarshalStoredVersionedContractByName(t
 		return errors.New("invalid json, no entry_point")
 	}
 
-	version, ok := tempMap["version"].(string)
-
-	if version == "None" {
-		s.Version = &types.CLValue{Type: types.CLTypeOption, Option: nil}
-	} else {
-		parsedVersion, err := strconv.ParseInt(version, 10, 32)
-		if err != nil {
-			return err
-		}
-		parsedVersion32 := uint32(parsedVersion)
-		s.Version = &types.CLValue{Type: types.CLTypeOption, Option: &types.CLValue{Type: types.CLTypeU32, U32: &parsedVersion32}}
+	var err error
+	s.Version, err = parseVersionJSON(tempMap["version"])
+	if err != nil {
+		return err
 	}
 
 	if tempMap["args"] == nil {
@@ -934,7 +982,6 @@ func (s *StoredVersionedContractByName) UnmarshalStoredVersionedContractByName(t
 
 	args := tempMap["args"].([]interface{})
 
-	var err error
 	s.Args, err = ParseRuntimeArgs(args)
 
 	if err != nil {
@@ -944,6 +991,49 @@ func (s *StoredVersionedContractByName) UnmarshalStoredVersionedContractByName(t
 	return nil
 }
 
+// versionToJSON returns the optional contract version as null or a number like the node and casper-client
+func versionToJSON(version *types.CLValue) *uint32 {
+	if version == nil || version.Option == nil {
+		return nil
+	}
+	return version.Option.U32
+}
+
+// parseVersionJSON parses the optional contract version, the strings "None" and "5" written by older versions of the sdk are accepted as well
+func parseVersionJSON(value interface{}) (*types.CLValue, error) {
+	var version uint64
+
+	switch v := value.(type) {
+	case nil:
+		return &types.CLValue{Type: types.CLTypeOption, Option: nil}, nil
+	case json.Number:
+		parsed, err := strconv.ParseUint(v.String(), 10, 32)
+		if err != nil {
+			return nil, err
+		}
+		version = parsed
+	case float64:
+		if v < 0 || v > math.MaxUint32 || v != math.Trunc(v) {
+			return nil, fmt.Errorf("invalid version %v", v)
+		}
+		version = uint64(v)
+	case string:
+		if v == "None" {
+			return &types.CLValue{Type: types.CLTypeOption, Option: nil}, nil
+		}
+		parsed, err := strconv.ParseUint(v, 10, 32)
+		if err != nil {
+			return nil, err
+		}
+		version = parsed
+	default:
+		return nil, fmt.Errorf("invalid version %v", v)
+	}
+
+	version32 := uint32(version)
+	return &types.CLValue{Type: types.CLTypeOption, Option: &types.CLValue{Type: types.CLTypeU32, U32: &version32}}, nil
+}
+
 type Transfer struct {
 	Tag  ExecutableDeployItemType
 	Args RuntimeArgs
@@ -963,12 +1053,8 @@ func buildTransfer(amount *big.Int, target *keypair.PublicKey, sourcePurse strin
 		return nil
 	}
 
-	var accountHex string
-
-	if target.Tag == keypair.KeyTagEd25519 {
-		accountHex = ed25519.AccountHash(target.PubKeyData)
-	} else {
-		// FIXME: implement secp256k1 module
+	accountHex, ok := accountHash(*target)
+	if !ok {
 		return nil
 	}
 
@@ -1039,9 +1125,9 @@ func (t Transfer) ToBytes() []byte {
 
 func (t Transfer) MarshalJSON() ([]byte, error) {
 	data := map[string]interface{}{
-		"Transfer": map[string]interface{}{
-			"args": t.Args.ToJSONInterface(),
-		},
+		"Transfer": struct {
+			Args []interface{} `json:"args"`
+		}{t.Args.ToJSONInterface()},
 	}
 
 	return json.Marshal(data)
@@ -1062,3 +1148,14 @@ func (t *Transfer) UnmarshalTransfer(tempMap map[string]interface{}) error {
 
 	return nil
 }
+
+// accountHash returns the hex encoded account hash of the public key, false for unknown key types
+func accountHash(publicKey keypair.PublicKey) (string, bool) {
+	switch publicKey.Tag {
+	case keypair.KeyTagEd25519:
+		return ed25519.AccountHash(publicKey.PubKeyData), true
+	case keypair.KeyTagSecp256k1:
+		return secp256k1.AccountHash(publicKey.PubKeyData), true
+	}
+	return "", false
+}
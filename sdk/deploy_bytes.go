@@ -0,0 +1,474 @@
+package sdk
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"sort"
+	"unicode/utf8"
+
+	"github.com/casper-ecosystem/casper-golang-sdk/keypair"
+	"github.com/casper-ecosystem/casper-golang-sdk/types"
+)
+
+var errNotEnoughBytes = errors.New("not enough bytes")
+
+// DeployFromBytes decodes a bytesrepr encoded deploy, all bytes of src have to belong to the deploy
+func DeployFromBytes(src []byte) (*Deploy, error) {
+	var deploy Deploy
+
+	n, err := deploy.FromBytes(src)
+	if err != nil {
+		return nil, err
+	}
+
+	if n != len(src) {
+		return nil, fmt.Errorf("failed to decode deploy: %d trailing bytes", len(src)-n)
+	}
+
+	return &deploy, nil
+}
+
+// ToBytes encodes the deploy the way the node does, the header followed by the hash, payment, session and approvals.
+// Like the node, which keeps the approvals in a sorted set, the approvals are written sorted by signer and signature
+func (d Deploy) ToBytes() ([]byte, error) {
+	res := append(d.Header.ToBytes(), d.Hash...)
+	res = append(res, d.Payment.ToBytes()...)
+	res = append(res, d.Session.ToBytes()...)
+
+	approvals := make([][]byte, len(d.Approvals))
+	for i, approval := range d.Approvals {
+		encoded, err := approval.ToBytes()
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode approval %d: %w", i, err)
+		}
+		approvals[i] = encoded
+	}
+
+	// the signer and signature lengths follow from their tags, so comparing the encodings orders by signer first
+	sort.Slice(approvals, func(i, j int) bool {
+		return bytes.Compare(approvals[i], approvals[j]) < 0
+	})
+
+	res = append(res, encodeUint32(uint32(len(approvals)))...)
+	for _, approval := range approvals {
+		res = append(res, approval...)
+	}
+
+	return res, nil
+}
+
+// FromBytes decodes the deploy at the start of src and returns the number of bytes read
+func (d *Deploy) FromBytes(src []byte) (int, error) {
+	r := &bytesReader{src: src}
+
+	d.Header = new(DeployHeader)
+	if err := r.decode("header", d.Header.FromBytes); err != nil {
+		return r.offset, err
+	}
+
+	hash, err := r.fixed(32)
+	if err != nil {
+		return r.offset, fmt.Errorf("failed to decode deploy hash: %w", err)
+	}
+	d.Hash = hash
+
+	d.Payment = new(ExecutableDeployItem)
+	if err := r.decode("payment", d.Payment.FromBytes); err != nil {
+		return r.offset, err
+	}
+
+	d.Session = new(ExecutableDeployItem)
+	if err := r.decode("session", d.Session.FromBytes); err != nil {
+		return r.offset, err
+	}
+
+	count, err := r.length(1)
+	if err != nil {
+		return r.offset, fmt.Errorf("failed to decode approvals: %w", err)
+	}
+
+	d.Approvals = make([]Approval, count)
+	for i := range d.Approvals {
+		if err := r.decode(fmt.Sprintf("approval %d", i), d.Approvals[i].FromBytes); err != nil {
+			return r.offset, err
+		}
+	}
+
+	return r.offset, nil
+}
+
+// FromBytes decodes the header at the start of src and returns the number of bytes read
+func (d *DeployHeader) FromBytes(src []byte) (int, error) {
+	r := &bytesReader{src: src}
+
+	account, err := r.publicKey()
+	if err != nil {
+		return r.offset, fmt.Errorf("invalid account: %w", err)
+	}
+	d.Account = account
+
+	timestamp, err := r.uint64()
+	if err != nil {
+		return r.offset, fmt.Errorf("invalid timestamp: %w", err)
+	}
+	d.Timestamp = Timestamp(timestamp)
+
+	ttl, err := r.uint64()
+	if err != nil {
+		return r.offset, fmt.Errorf("invalid ttl: %w", err)
+	}
+	d.TTL = Duration(ttl)
+
+	d.GasPrice, err = r.uint64()
+	if err != nil {
+		return r.offset, fmt.Errorf("invalid gas price: %w", err)
+	}
+
+	d.BodyHash, err = r.fixed(32)
+	if err != nil {
+		return r.offset, fmt.Errorf("invalid body hash: %w", err)
+	}
+
+	count, err := r.length(32)
+	if err != nil {
+		return r.offset, fmt.Errorf("invalid dependencies: %w", err)
+	}
+
+	d.Dependencies = make([][]byte, count)
+	for i := range d.Dependencies {
+		d.Dependencies[i], err = r.fixed(32)
+		if err != nil {
+			return r.offset, fmt.Errorf("invalid dependency %d: %w", i, err)
+		}
+	}
+
+	d.ChainName, err = r.string()
+	if err != nil {
+		return r.offset, fmt.Errorf("invalid chain name: %w", err)
+	}
+
+	return r.offset, nil
+}
+
+// FromBytes decodes the executable deploy item at the start of src and returns the number of bytes read
+func (e *ExecutableDeployItem) FromBytes(src []byte) (int, error) {
+	r := &bytesReader{src: src}
+
+	tag, err := r.byte()
+	if err != nil {
+		return r.offset, err
+	}
+
+	*e = ExecutableDeployItem{Type: ExecutableDeployItemType(tag)}
+
+	switch e.Type {
+	case ExecutableDeployItemTypeModuleBytes:
+		e.ModuleBytes = &ModuleBytes{Tag: e.Type}
+		if e.ModuleBytes.ModuleBytes, err = r.bytes(); err != nil {
+			return r.offset, fmt.Errorf("invalid module bytes: %w", err)
+		}
+		err = r.decode("args", e.ModuleBytes.Args.FromBytes)
+	case ExecutableDeployItemTypeStoredContractByHash:
+		e.StoredContractByHash = &StoredContractByHash{Tag: e.Type}
+		if e.StoredContractByHash.Hash, err = r.hash(); err != nil {
+			return r.offset, fmt.Errorf("invalid contract hash: %w", err)
+		}
+		if e.StoredContractByHash.Entrypoint, err = r.string(); err != nil {
+			return r.offset, fmt.Errorf("invalid entry point: %w", err)
+		}
+		err = r.decode("args", e.StoredContractByHash.Args.FromBytes)
+	case ExecutableDeployItemTypeStoredContractByName:
+		e.StoredContractByName = &StoredContractByName{Tag: e.Type}
+		if e.StoredContractByName.Name, err = r.string(); err != nil {
+			return r.offset, fmt.Errorf("invalid contract name: %w", err)
+		}
+		if e.StoredContractByName.Entrypoint, err = r.string(); err != nil {
+			return r.offset, fmt.Errorf("invalid entry point: %w", err)
+		}
+		err = r.decode("args", e.StoredContractByName.Args.FromBytes)
+	case ExecutableDeployItemTypeStoredVersionedContractByHash:
+		e.StoredVersionedContractByHash = &StoredVersionedContractByHash{Tag: e.Type}
+		if e.StoredVersionedContractByHash.Hash, err = r.hash(); err != nil {
+			return r.offset, fmt.Errorf("invalid contract package hash: %w", err)
+		}
+		if e.StoredVersionedContractByHash.Version, err = r.version(); err != nil {
+			return r.offset, fmt.Errorf("invalid version: %w", err)
+		}
+		if e.StoredVersionedContractByHash.Entrypoint, err = r.string(); err != nil {
+			return r.offset, fmt.Errorf("invalid entry point: %w", err)
+		}
+		err = r.decode("args", e.StoredVersionedContractByHash.Args.FromBytes)
+	case ExecutableDeployItemTypeStoredVersionedContractByName:
+		e.StoredVersionedContractByName = &StoredVersionedContractByName{Tag: e.Type}
+		if e.StoredVersionedContractByName.Name, err = r.string(); err != nil {
+			return r.offset, fmt.Errorf("invalid contract package name: %w", err)
+		}
+		if e.StoredVersionedContractByName.Version, err = r.version(); err != nil {
+			return r.offset, fmt.Errorf("invalid version: %w", err)
+		}
+		if e.StoredVersionedContractByName.Entrypoint, err = r.string(); err != nil {
+			return r.offset, fmt.Errorf("invalid entry point: %w", err)
+		}
+		err = r.decode("args", e.StoredVersionedContractByName.Args.FromBytes)
+	case ExecutableDeployItemTypeTransfer:
+		e.Transfer = &Transfer{Tag: e.Type}
+		err = r.decode("args", e.Transfer.Args.FromBytes)
+	default:
+		return r.offset, fmt.Errorf("invalid executable deploy item tag %d", tag)
+	}
+
+	return r.offset, err
+}
+
+// FromBytes decodes the runtime args at the start of src and returns the number of bytes read
+func (r *RuntimeArgs) FromBytes(src []byte) (int, error) {
+	reader := &bytesReader{src: src}
+
+	// each arg takes at least 4 bytes for its name, 4 for its value and 1 for its type
+	count, err := reader.length(9)
+	if err != nil {
+		return reader.offset, err
+	}
+
+	r.KeyOrder = make([]string, 0, count)
+	r.Args = make(map[string]Value, count)
+
+	for i := 0; i < count; i++ {
+		name, err := reader.string()
+		if err != nil {
+			return reader.offset, fmt.Errorf("invalid name of arg %d: %w", i, err)
+		}
+		if _, ok := r.Args[name]; ok {
+			return reader.offset, fmt.Errorf("duplicate arg %q", name)
+		}
+
+		var value Value
+		if err := reader.decode(fmt.Sprintf("arg %q", name), value.FromBytes); err != nil {
+			return reader.offset, err
+		}
+
+		r.KeyOrder = append(r.KeyOrder, name)
+		r.Args[name] = value
+	}
+
+	return reader.offset, nil
+}
+
+// FromBytes decodes the value bytes followed by the cl type at the start of src and returns the number of bytes read
+func (v *Value) FromBytes(src []byte) (int, error) {
+	r := &bytesReader{src: src}
+
+	valueBytes, err := r.bytes()
+	if err != nil {
+		return r.offset, err
+	}
+
+	var clType types.CLTypeInfo
+	n, err := types.UnmarshalCLTypeInfo(src[r.offset:], &clType)
+	r.offset += n
+	if err != nil {
+		return r.offset, err
+	}
+	if err := clType.Validate(); err != nil {
+		return r.offset, err
+	}
+
+	*v = newValue(clType, valueBytes)
+	return r.offset, nil
+}
+
+// ToBytes encodes the approval as the signer followed by the signature
+func (a Approval) ToBytes() ([]byte, error) {
+	if a.Signer.Tag != keypair.KeyTagEd25519 && a.Signer.Tag != keypair.KeyTagSecp256k1 {
+		return nil, fmt.Errorf("invalid signer tag %d", a.Signer.Tag)
+	}
+	if a.Signature.Tag != keypair.KeyTagEd25519 && a.Signature.Tag != keypair.KeyTagSecp256k1 {
+		return nil, fmt.Errorf("invalid signature tag %d", a.Signature.Tag)
+	}
+
+	signer, err := a.Signer.ToBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	return append(append(signer, byte(a.Signature.Tag)), a.Signature.SignatureData...), nil
+}
+
+// FromBytes decodes the approval at the start of src and returns the number of bytes read
+func (a *Approval) FromBytes(src []byte) (int, error) {
+	r := &bytesReader{src: src}
+
+	signer, err := r.publicKey()
+	if err != nil {
+		return r.offset, fmt.Errorf("invalid signer: %w", err)
+	}
+	a.Signer = signer
+
+	tag, err := r.byte()
+	if err != nil {
+		return r.offset, fmt.Errorf("invalid signature: %w", err)
+	}
+
+	var size int
+	switch keypair.KeyTag(tag) {
+	case keypair.KeyTagEd25519, keypair.KeyTagSecp256k1:
+		size = 64
+	default:
+		return r.offset, fmt.Errorf("invalid signature tag %d", tag)
+	}
+
+	data, err := r.fixed(size)
+	if err != nil {
+		return r.offset, fmt.Errorf("invalid signature: %w", err)
+	}
+	a.Signature = keypair.Signature{Tag: keypair.KeyTag(tag), SignatureData: data}
+
+	return r.offset, nil
+}
+
+func encodeUint32(v uint32) []byte {
+	res := make([]byte, 4)
+	binary.LittleEndian.PutUint32(res, v)
+	return res
+}
+
+// bytesReader reads bytesrepr encoded fields from src, checking that enough bytes are left before each read
+type bytesReader struct {
+	src    []byte
+	offset int
+}
+
+// decode runs the FromBytes function of a nested field on the remaining bytes
+func (r *bytesReader) decode(name string, fromBytes func([]byte) (int, error)) error {
+	n, err := fromBytes(r.src[r.offset:])
+	r.offset += n
+	if err != nil {
+		return fmt.Errorf("failed to decode %s: %w", name, err)
+	}
+	return nil
+}
+
+func (r *bytesReader) fixed(size int) ([]byte, error) {
+	if size > len(r.src)-r.offset {
+		return nil, errNotEnoughBytes
+	}
+
+	res := append(make([]byte, 0, size), r.src[r.offset:r.offset+size]...)
+	r.offset += size
+	return res, nil
+}
+
+func (r *bytesReader) byte() (byte, error) {
+	res, err := r.fixed(1)
+	if err != nil {
+		return 0, err
+	}
+	return res[0], nil
+}
+
+func (r *bytesReader) uint32() (uint32, error) {
+	res, err := r.fixed(4)
+	if err != nil {
+		return 0, err
+	}
+	return binary.LittleEndian.Uint32(res), nil
+}
+
+func (r *bytesReader) uint64() (uint64, error) {
+	res, err := r.fixed(8)
+	if err != nil {
+		return 0, err
+	}
+	return binary.LittleEndian.Uint64(res), nil
+}
+
+// length reads the element count of a list, which has to fit into the remaining bytes with the minimum size of an element
+func (r *bytesReader) length(minElementSize int) (int, error) {
+	count, err := r.uint32()
+	if err != nil {
+		return 0, err
+	}
+
+	if uint64(count)*uint64(minElementSize) > uint64(len(r.src)-r.offset) {
+		return 0, fmt.Errorf("%w for %d elements", errNotEnoughBytes, count)
+	}
+	return int(count), nil
+}
+
+func (r *bytesReader) bytes() ([]byte, error) {
+	length, err := r.length(1)
+	if err != nil {
+		return nil, err
+	}
+	return r.fixed(length)
+}
+
+func (r *bytesReader) string() (string, error) {
+	res, err := r.bytes()
+	if err != nil {
+		return "", err
+	}
+	if !utf8.Valid(res) {
+		return "", errors.New("string is not valid utf-8")
+	}
+	return string(res), nil
+}
+
+func (r *bytesReader) hash() ([32]byte, error) {
+	var hash [32]byte
+
+	res, err := r.fixed(32)
+	if err != nil {
+		return hash, err
+	}
+
+	copy(hash[:], res)
+	return hash, nil
+}
+
+func (r *bytesReader) publicKey() (keypair.PublicKey, error) {
+	tag, err := r.byte()
+	if err != nil {
+		return keypair.PublicKey{}, err
+	}
+
+	var size int
+	switch keypair.KeyTag(tag) {
+	case keypair.KeyTagEd25519:
+		size = 32
+	case keypair.KeyTagSecp256k1:
+		size = 33
+	default:
+		return keypair.PublicKey{}, fmt.Errorf("invalid public key tag %d", tag)
+	}
+
+	data, err := r.fixed(size)
+	if err != nil {
+		return keypair.PublicKey{}, err
+	}
+
+	return keypair.PublicKey{Tag: keypair.KeyTag(tag), PubKeyData: data}, nil
+}
+
+// version reads the optional contract version of the versioned executable deploy items
+func (r *bytesReader) version() (*types.CLValue, error) {
+	present, err := r.byte()
+	if err != nil {
+		return nil, err
+	}
+
+	switch present {
+	case 0:
+		return &types.CLValue{Type: types.CLTypeOption, Option: nil}, nil
+	case 1:
+		version, err := r.uint32()
+		if err != nil {
+			return nil, err
+		}
+		return &types.CLValue{Type: types.CLTypeOption, Option: &types.CLValue{Type: types.CLTypeU32, U32: &version}}, nil
+	}
+
+	return nil, fmt.Errorf("invalid option tag %d", present)
+}
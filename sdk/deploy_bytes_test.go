@@ -0,0 +1,158 @@
+package sdk
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"math/big"
+	"testing"
+
+	"github.com/casper-ecosystem/casper-golang-sdk/keypair/ed25519"
+	"github.com/casper-ecosystem/casper-golang-sdk/types"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/crypto/blake2b"
+)
+
+const testDeployJSON = "{\"hash\":\"48b33972cdc075d82363279640490b64bcac26cd540c8cf16da688d400c86b66\",\"header\":{\"account\":\"01d995c93ac47e763433b5ec973cac464c7343d76d6bd47c936cf8ce5d83032061\",\"timestamp\":\"2021-09-13T17:51:59.181Z\",\"ttl\":\"30m0s\",\"gas_price\":1,\"body_hash\":\"f9608668e24e68cad0c930016e1885d1d82fdb655b254130c32b586c4443af37\",\"dependencies\":[],\"chain_name\":\"casper-test\"},\"payment\":{\"ModuleBytes\":{\"args\":[[\"amount\",{\"bytes\":\"021027\",\"cl_type\":\"U512\"}]],\"module_bytes\":\"\"}},\"session\":{\"Transfer\":{\"args\":[[\"amount\",{\"bytes\":\"0400f90295\",\"cl_type\":\"U512\"}],[\"target\",{\"bytes\":\"a6d3d9fb1044cf5db1b30ad3f8f2c2c69e48ae69ab8aae6f02d69b0d0faa9e3d\",\"cl_type\":{\"ByteArray\":32}}],[\"id\",{\"bytes\":\"010100000000000000\",\"cl_type\":{\"Option\":\"U64\"}}]]}},\"approvals\":[]}"
+
+func TestDeployFromBytes(t *testing.T) {
+	var deploy Deploy
+	if !assert.NoError(t, json.Unmarshal([]byte(testDeployJSON), &deploy)) {
+		return
+	}
+	deploy.SignDeploy(sourceKeyPair)
+
+	serialized, err := deploy.ToBytes()
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	decoded, err := DeployFromBytes(serialized)
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	assert.Equal(t, "48b33972cdc075d82363279640490b64bcac26cd540c8cf16da688d400c86b66", hex.EncodeToString(decoded.Hash))
+	assert.Equal(t, deploy.Header, decoded.Header)
+	assert.Equal(t, []string{"amount", "target", "id"}, decoded.Session.Transfer.Args.KeyOrder)
+	assert.Equal(t, "0400f90295", decoded.Session.Transfer.Args.Args["amount"].StringBytes)
+	assert.Equal(t, types.OptionCLType(types.SimpleCLType(types.CLTypeU64)), decoded.Session.Transfer.Args.Args["id"].CLType())
+	assert.Len(t, decoded.Approvals, 1)
+	reencoded, err := decoded.ToBytes()
+	assert.NoError(t, err)
+	assert.Equal(t, serialized, reencoded)
+
+	// the decoded deploy can be re-hashed and its approval verified
+	assert.NoError(t, decoded.ValidateDeploy())
+
+	marshaled, err := json.Marshal(decoded)
+	assert.NoError(t, err)
+	expected, err := json.Marshal(deploy)
+	assert.NoError(t, err)
+	assert.JSONEq(t, string(expected), string(marshaled))
+
+	for i := 0; i < len(serialized); i++ {
+		_, err := DeployFromBytes(serialized[:i])
+		assert.Error(t, err, "truncated to %d bytes", i)
+	}
+
+	_, err = DeployFromBytes(append(serialized, 0))
+	assert.Error(t, err)
+}
+
+func TestDeploy_ToBytesApprovals(t *testing.T) {
+	var deploy Deploy
+	if !assert.NoError(t, json.Unmarshal([]byte(testDeployJSON), &deploy)) {
+		return
+	}
+
+	deploy.SignDeploy(ed25519.Ed25519FromSeed(bytes.Repeat([]byte{0x02}, 32)))
+	deploy.SignDeploy(sourceKeyPair)
+	deploy.SignDeploy(ed25519.Ed25519FromSeed(bytes.Repeat([]byte{0x01}, 32)))
+
+	sorted, err := deploy.ToBytes()
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	// the approvals are encoded in the order of the node regardless of the order they were added in
+	deploy.Approvals[0], deploy.Approvals[2] = deploy.Approvals[2], deploy.Approvals[0]
+	reordered, err := deploy.ToBytes()
+	assert.NoError(t, err)
+	assert.Equal(t, sorted, reordered)
+
+	decoded, err := DeployFromBytes(sorted)
+	if !assert.NoError(t, err) {
+		return
+	}
+	for i := 1; i < len(decoded.Approvals); i++ {
+		previous, _ := decoded.Approvals[i-1].Signer.ToBytes()
+		current, _ := decoded.Approvals[i].Signer.ToBytes()
+		assert.True(t, bytes.Compare(previous, current) < 0)
+	}
+
+	deploy.Approvals = append(deploy.Approvals, Approval{})
+	_, err = deploy.ToBytes()
+	assert.Error(t, err)
+}
+
+func TestExecutableDeployItem_FromBytes(t *testing.T) {
+	var hash [32]byte
+	hash[0] = 0x2a
+
+	args, err := MarshalRuntimeArgs(struct {
+		Amount big.Int           `casper:"amount,u512"`
+		Meta   map[string]string `casper:"meta,map"`
+		ID     *uint64           `casper:"id"`
+	}{Amount: *big.NewInt(1000), Meta: map[string]string{"a": "1"}})
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	for _, item := range []*ExecutableDeployItem{
+		NewModuleBytes([]byte{0x00, 0x61, 0x73, 0x6d}, args),
+		NewStoredContractByHash(hash, "call", args),
+		NewStoredContractByName("counter", "call", args),
+		NewStoredVersionedContractByHash(hash, 2, "call", args),
+		NewStoredVersionedContractByHashWithoutVersion(hash, "call", args),
+		NewStoredVersionedContractByName("counter", 2, "call", args),
+		NewStoredVersionedContractByNameWithoutVersion("counter", "call", args),
+		NewTransfer(big.NewInt(2500000000), dest, "", 1),
+	} {
+		serialized := item.ToBytes()
+
+		var decoded ExecutableDeployItem
+		n, err := decoded.FromBytes(append(serialized, 0xff))
+		if !assert.NoError(t, err, "item type %d", item.Type) {
+			continue
+		}
+		assert.Equal(t, len(serialized), n)
+		assert.Equal(t, item.Type, decoded.Type)
+		assert.Equal(t, serialized, decoded.ToBytes(), "item type %d", item.Type)
+	}
+
+	_, err = new(ExecutableDeployItem).FromBytes([]byte{6})
+	assert.Error(t, err)
+}
+
+func TestDeployHeader_FromBytes(t *testing.T) {
+	dependency := bytes.Repeat([]byte{0x1a}, 32)
+	bodyHash := blake2b.Sum256(nil)
+	header := NewDeployHeader(*source, 1631555519181, 1800000, 1, bodyHash[:], [][]byte{dependency}, "casper-test")
+
+	serialized := header.ToBytes()
+	// a u32 count followed by each deploy hash as 32 fixed bytes
+	assert.Equal(t, "01000000"+hex.EncodeToString(dependency), hex.EncodeToString(serialized[89:125]))
+
+	var decoded DeployHeader
+	n, err := decoded.FromBytes(serialized)
+	assert.NoError(t, err)
+	assert.Equal(t, len(serialized), n)
+	assert.Equal(t, *header, decoded)
+
+	// the dependency count doesn't fit into the remaining bytes
+	tooMany := append([]byte{}, serialized[:89]...)
+	tooMany = append(tooMany, 0xff, 0xff, 0xff, 0xff)
+	_, err = decoded.FromBytes(tooMany)
+	assert.Error(t, err)
+}
@@ -0,0 +1,66 @@
+package sdk
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+)
+
+// ReadDeploy reads a deploy in the json format of casper-client and validates its hashes and approvals
+func ReadDeploy(r io.Reader) (*Deploy, error) {
+	var deploy Deploy
+
+	decoder := json.NewDecoder(r)
+	if err := decoder.Decode(&deploy); err != nil {
+		return nil, fmt.Errorf("failed to read deploy: %w", err)
+	}
+
+	if err := deploy.ValidateDeploy(); err != nil {
+		return nil, err
+	}
+
+	return &deploy, nil
+}
+
+// WriteDeploy writes the deploy as indented json like casper-client, so the client can send or sign it
+func WriteDeploy(w io.Writer, deploy *Deploy) error {
+	file := *deploy
+	if file.Approvals == nil {
+		file.Approvals = make([]Approval, 0)
+	}
+
+	data, err := json.MarshalIndent(file, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to write deploy: %w", err)
+	}
+
+	_, err = w.Write(data)
+	return err
+}
+
+// ReadDeployFile reads a deploy file created by casper-client make-deploy, make-transfer or sign-deploy
+func ReadDeployFile(path string) (*Deploy, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	return ReadDeploy(file)
+}
+
+// WriteDeployFile writes the deploy to a file which can be used with casper-client sign-deploy and send-deploy
+func WriteDeployFile(path string, deploy *Deploy) error {
+	file, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+
+	if err := WriteDeploy(file, deploy); err != nil {
+		file.Close()
+		return err
+	}
+
+	return file.Close()
+}
@@ -0,0 +1,125 @@
+package sdk
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReadDeployFile(t *testing.T) {
+	transfer, err := ReadDeployFile("testdata/transfer_deploy.json")
+	if assert.NoError(t, err) {
+		assert.Equal(t, Duration(30*time.Minute/time.Millisecond), transfer.Header.TTL)
+		assert.True(t, transfer.Session.IsTransfer())
+		assert.Equal(t, json.RawMessage(`1`), transfer.Session.Transfer.Args.Args["id"].Parsed)
+		assert.Len(t, transfer.Approvals, 1)
+	}
+
+	moduleBytes, err := ReadDeployFile("testdata/module_bytes_deploy.json")
+	if assert.NoError(t, err) {
+		assert.Equal(t, Duration(90*time.Minute/time.Millisecond), moduleBytes.Header.TTL)
+		wasm, err := os.ReadFile("testdata/session.wasm")
+		assert.NoError(t, err)
+		assert.Equal(t, wasm, moduleBytes.Session.ModuleBytes.ModuleBytes)
+		assert.Len(t, moduleBytes.Approvals, 1)
+
+		// a deploy to be signed elsewhere has no approvals yet
+		unsigned := *moduleBytes
+		unsigned.Approvals = nil
+		var written bytes.Buffer
+		assert.NoError(t, WriteDeploy(&written, &unsigned))
+		assert.Contains(t, written.String(), `"approvals": []`)
+	}
+
+	versioned, err := ReadDeployFile("testdata/versioned_contract_deploy.json")
+	if assert.NoError(t, err) {
+		assert.Equal(t, Duration(24*time.Hour/time.Millisecond), versioned.Header.TTL)
+		assert.Equal(t, "48b33972cdc075d82363279640490b64bcac26cd540c8cf16da688d400c86b66", hex.EncodeToString(versioned.Header.Dependencies[0]))
+		assert.Equal(t, uint32(2), *versioned.Session.StoredVersionedContractByName.Version.Option.U32)
+		assert.Equal(t, json.RawMessage(`18446744073709551615`), versioned.Session.StoredVersionedContractByName.Args.Args["id"].Parsed)
+		assert.Len(t, versioned.Approvals, 2)
+	}
+}
+
+func TestWriteDeployFile(t *testing.T) {
+	dir := t.TempDir()
+
+	for _, name := range []string{"transfer_deploy.json", "module_bytes_deploy.json", "versioned_contract_deploy.json"} {
+		fixture, err := os.ReadFile(filepath.Join("testdata", name))
+		if !assert.NoError(t, err) {
+			continue
+		}
+
+		deploy, err := ReadDeployFile(filepath.Join("testdata", name))
+		if !assert.NoError(t, err, name) {
+			continue
+		}
+
+		path := filepath.Join(dir, name)
+		if !assert.NoError(t, WriteDeployFile(path, deploy), name) {
+			continue
+		}
+
+		// casper-client has to be able to read the file back, so it has to be written the way the client writes it
+		written, err := os.ReadFile(path)
+		if assert.NoError(t, err) {
+			assert.Equal(t, string(bytes.TrimSpace(fixture)), string(bytes.TrimSpace(written)), name)
+		}
+
+		_, err = ReadDeployFile(path)
+		assert.NoError(t, err, name)
+	}
+}
+
+func TestReadDeploy_Invalid(t *testing.T) {
+	fixture, err := os.ReadFile("testdata/transfer_deploy.json")
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	// the ttl is part of the deploy hash
+	_, err = ReadDeploy(strings.NewReader(strings.Replace(string(fixture), `"ttl": "30m"`, `"ttl": "1h"`, 1)))
+	assert.True(t, errors.Is(err, ErrInvalidDeployHash))
+
+	_, err = ReadDeploy(strings.NewReader(strings.Replace(string(fixture), `"gas_price": 1`, `"gas_price": "1"`, 1)))
+	assert.Error(t, err)
+
+	_, err = ReadDeployFile("testdata/missing_deploy.json")
+	assert.True(t, errors.Is(err, os.ErrNotExist))
+}
+
+func TestDuration_JSON(t *testing.T) {
+	cases := map[time.Duration]string{
+		0:                                  "0s",
+		500 * time.Millisecond:             "500ms",
+		30 * time.Minute:                   "30m",
+		90 * time.Minute:                   "1h 30m",
+		24 * time.Hour:                     "1day",
+		50*time.Hour + time.Second:         "2days 2h 1s",
+		2630016 * time.Second:              "1month",
+		31557600*time.Second + 2*time.Hour: "1year 2h",
+	}
+
+	for duration, expected := range cases {
+		marshaled, err := json.Marshal(Duration(duration / time.Millisecond))
+		assert.NoError(t, err)
+		assert.Equal(t, `"`+expected+`"`, string(marshaled))
+
+		var parsed Duration
+		assert.NoError(t, json.Unmarshal(marshaled, &parsed))
+		assert.Equal(t, Duration(duration/time.Millisecond), parsed)
+	}
+
+	// go durations written by older versions of the sdk
+	var parsed Duration
+	assert.NoError(t, json.Unmarshal([]byte(`"30m0s"`), &parsed))
+	assert.Equal(t, Duration(1800000), parsed)
+}
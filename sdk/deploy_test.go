@@ -3,6 +3,7 @@ package sdk
 import (
 	"encoding/hex"
 	"encoding/json"
+	"errors"
 	"math/big"
 	"strconv"
 	"testing"
@@ -12,6 +13,7 @@ import (
 	"github.com/casper-ecosystem/casper-golang-sdk/keypair/ed25519"
 	"github.com/casper-ecosystem/casper-golang-sdk/types"
 	"github.com/stretchr/testify/assert"
+	"github.com/tendermint/tendermint/crypto/secp256k1"
 	"golang.org/x/crypto/blake2b"
 )
 
@@ -123,7 +125,7 @@ func TestDeployUtil_HashBodyAndMarshalJSONCorrectly(t *testing.T) {
 	}
 
 	assert.Equal(t,
-		"{\"hash\":\"48b33972cdc075d82363279640490b64bcac26cd540c8cf16da688d400c86b66\",\"header\":{\"account\":\"01d995c93ac47e763433b5ec973cac464c7343d76d6bd47c936cf8ce5d83032061\",\"timestamp\":\"2021-09-13T17:51:59.181Z\",\"ttl\":\"30m0s\",\"gas_price\":1,\"body_hash\":\"f9608668e24e68cad0c930016e1885d1d82fdb655b254130c32b586c4443af37\",\"dependencies\":[],\"chain_name\":\"casper-test\"},\"payment\":{\"ModuleBytes\":{\"args\":[[\"amount\",{\"bytes\":\"021027\",\"cl_type\":\"U512\"}]],\"module_bytes\":\"\"}},\"session\":{\"Transfer\":{\"args\":[[\"amount\",{\"bytes\":\"0400f90295\",\"cl_type\":\"U512\"}],[\"target\",{\"bytes\":\"a6d3d9fb1044cf5db1b30ad3f8f2c2c69e48ae69ab8aae6f02d69b0d0faa9e3d\",\"cl_type\":{\"ByteArray\":32}}],[\"id\",{\"bytes\":\"010100000000000000\",\"cl_type\":{\"Option\":\"U64\"}}]]}},\"approvals\":[]}",
+		"{\"hash\":\"48b33972cdc075d82363279640490b64bcac26cd540c8cf16da688d400c86b66\",\"header\":{\"account\":\"01d995c93ac47e763433b5ec973cac464c7343d76d6bd47c936cf8ce5d83032061\",\"timestamp\":\"2021-09-13T17:51:59.181Z\",\"ttl\":\"30m\",\"gas_price\":1,\"body_hash\":\"f9608668e24e68cad0c930016e1885d1d82fdb655b254130c32b586c4443af37\",\"dependencies\":[],\"chain_name\":\"casper-test\"},\"payment\":{\"ModuleBytes\":{\"module_bytes\":\"\",\"args\":[[\"amount\",{\"cl_type\":\"U512\",\"bytes\":\"021027\"}]]}},\"session\":{\"Transfer\":{\"args\":[[\"amount\",{\"cl_type\":\"U512\",\"bytes\":\"0400f90295\"}],[\"target\",{\"cl_type\":{\"ByteArray\":32},\"bytes\":\"a6d3d9fb1044cf5db1b30ad3f8f2c2c69e48ae69ab8aae6f02d69b0d0faa9e3d\"}],[\"id\",{\"cl_type\":{\"Option\":\"U64\"},\"bytes\":\"010100000000000000\"}]]}},\"approvals\":[]}",
 		string(marshal))
 }
 
@@ -186,7 +188,7 @@ func TestDeployUtil_MarshalTransfer(t *testing.T) {
 		return
 	}
 
-	assert.Equal(t, "{\"Transfer\":{\"args\":[[\"amount\",{\"bytes\":\"0400f90295\",\"cl_type\":\"U512\"}],[\"target\",{\"bytes\":\"a6d3d9fb1044cf5db1b30ad3f8f2c2c69e48ae69ab8aae6f02d69b0d0faa9e3d\",\"cl_type\":{\"ByteArray\":32}}],[\"id\",{\"bytes\":\"010100000000000000\",\"cl_type\":{\"Option\":\"U64\"}}]]}}",
+	assert.Equal(t, "{\"Transfer\":{\"args\":[[\"amount\",{\"cl_type\":\"U512\",\"bytes\":\"0400f90295\"}],[\"target\",{\"cl_type\":{\"ByteArray\":32},\"bytes\":\"a6d3d9fb1044cf5db1b30ad3f8f2c2c69e48ae69ab8aae6f02d69b0d0faa9e3d\"}],[\"id\",{\"cl_type\":{\"Option\":\"U64\"},\"bytes\":\"010100000000000000\"}]]}}",
 		string(marshalJSON))
 }
 
@@ -199,10 +201,31 @@ func TestDeployUtil_NewTransferWithoutId(t *testing.T) {
 		return
 	}
 
-	assert.Equal(t, "{\"Transfer\":{\"args\":[[\"amount\",{\"bytes\":\"0400f90295\",\"cl_type\":\"U512\"}],[\"target\",{\"bytes\":\"a6d3d9fb1044cf5db1b30ad3f8f2c2c69e48ae69ab8aae6f02d69b0d0faa9e3d\",\"cl_type\":{\"ByteArray\":32}}],[\"id\",{\"bytes\":\"00\",\"cl_type\":{\"Option\":\"U64\"}}]]}}",
+	assert.Equal(t, "{\"Transfer\":{\"args\":[[\"amount\",{\"cl_type\":\"U512\",\"bytes\":\"0400f90295\"}],[\"target\",{\"cl_type\":{\"ByteArray\":32},\"bytes\":\"a6d3d9fb1044cf5db1b30ad3f8f2c2c69e48ae69ab8aae6f02d69b0d0faa9e3d\"}],[\"id\",{\"cl_type\":{\"Option\":\"U64\"},\"bytes\":\"00\"}]]}}",
 		string(marshalJSON))
 }
 
+func TestDeployUtil_NewTransferSecp256k1(t *testing.T) {
+	pubKeyHex := "03791c1a7414511e9b6a05b83647c5d9ccffb2c6b556454eabd00d540faac64295"
+	pubKey, _ := hex.DecodeString(pubKeyHex)
+	target := &keypair.PublicKey{Tag: keypair.KeyTagSecp256k1, PubKeyData: pubKey}
+
+	transfer := NewTransfer(big.NewInt(2500000000), target, "", 1)
+	if !assert.NotNil(t, transfer) {
+		return
+	}
+
+	accountHash := blake2b.Sum256(append([]byte("secp256k1\x00"), pubKey...))
+
+	marshalJSON, err := transfer.MarshalJSON()
+	if err != nil {
+		t.Errorf("can't marshal %v", err)
+		return
+	}
+
+	assert.Contains(t, string(marshalJSON), "[\"target\",{\"cl_type\":{\"ByteArray\":32},\"bytes\":\""+hex.EncodeToString(accountHash[:])+"\"")
+}
+
 func TestDeployUtil_UnmarshalTransfer(t *testing.T) {
 	var transfer ExecutableDeployItem
 
@@ -268,7 +291,7 @@ func TestDeployUtil_MarshalStoredContractByHash(t *testing.T) {
 		return
 	}
 
-	assert.Equal(t, "{\"StoredContractByHash\":{\"args\":[[\"amount\",{\"bytes\":\"0400f90295\",\"cl_type\":\"U512\"}]],\"entry_point\":\"test\",\"hash\":\"d995c93ac47e763433b5ec973cac464c7343d76d6bd47c936cf8ce5d83032061\"}}",
+	assert.Equal(t, "{\"StoredContractByHash\":{\"hash\":\"d995c93ac47e763433b5ec973cac464c7343d76d6bd47c936cf8ce5d83032061\",\"entry_point\":\"test\",\"args\":[[\"amount\",{\"cl_type\":\"U512\",\"bytes\":\"0400f90295\"}]]}}",
 		string(marshalJSON))
 }
 
@@ -302,7 +325,7 @@ func TestDeployUtil_MarshalStoredContractByHashMapValue(t *testing.T) {
 		return
 	}
 
-	assert.Equal(t, "{\"StoredContractByHash\":{\"args\":[[\"storage_id\",{\"bytes\":\"050000004142432d32\",\"cl_type\":\"String\"}],[\"storage_data\",{\"bytes\":\"010000000400000032312d324000000033623238633238636664633165386666663030613165346630313931663933383838316330613831396362383435653365366536303037383235336564613662\",\"cl_type\":{\"Map\":{\"key\":\"String\",\"value\":\"String\"}}}]],\"entry_point\":\"store_batch\",\"hash\":\"28ce14c210c53735d43eafef7f4446fb51c0761075c553029a9eb30988a0caa1\"}}",
+	assert.Equal(t, "{\"StoredContractByHash\":{\"hash\":\"28ce14c210c53735d43eafef7f4446fb51c0761075c553029a9eb30988a0caa1\",\"entry_point\":\"store_batch\",\"args\":[[\"storage_id\",{\"cl_type\":\"String\",\"bytes\":\"050000004142432d32\"}],[\"storage_data\",{\"cl_type\":{\"Map\":{\"key\":\"String\",\"value\":\"String\"}},\"bytes\":\"010000000400000032312d324000000033623238633238636664633165386666663030613165346630313931663933383838316330613831396362383435653365366536303037383235336564613662\"}]]}}",
 		string(marshalJSON))
 }
 
@@ -348,6 +371,57 @@ func TestDeployUtil_UnmarshalStoredContractByHash(t *testing.T) {
 	assert.Equal(t, "3b28c28cfdc1e8fff00a1e4f0191f938881c0a819cb845e3e6e60078253eda6b", *clMap.Map.Raw["21-2"].String)
 }
 
+func TestDeployUtil_UnmarshalNestedArgs(t *testing.T) {
+	var storedContractByHash ExecutableDeployItem
+
+	err := json.Unmarshal([]byte("{\"StoredContractByHash\":{\"hash\":\"28ce14c210c53735d43eafef7f4446fb51c0761075c553029a9eb30988a0caa1\",\"entry_point\":\"mint\",\"args\":[[\"ids\",{\"cl_type\":{\"List\":{\"Option\":\"U64\"}},\"bytes\":\"0100000001ff00000000000000\"}],[\"meta\",{\"cl_type\":{\"Map\":{\"key\":\"String\",\"value\":{\"List\":\"Key\"}}},\"bytes\":\"00000000\"}]]}}"),
+		&storedContractByHash)
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	args := storedContractByHash.StoredContractByHash.Args
+	assert.Equal(t, "List<Option<U64>>", args.Args["ids"].CLType().String())
+	assert.Equal(t, "Map<String, List<Key>>", args.Args["meta"].CLType().String())
+
+	assert.Equal(t, "0d0000000100000001ff000000000000000e0d05", hex.EncodeToString(args.Args["ids"].ToBytes()))
+	assert.Equal(t, "0400000000000000110a0e0b", hex.EncodeToString(args.Args["meta"].ToBytes()))
+
+	marshalJSON, err := storedContractByHash.MarshalJSON()
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	assert.Contains(t, string(marshalJSON), "[\"ids\",{\"cl_type\":{\"List\":{\"Option\":\"U64\"}},\"bytes\":\"0100000001ff00000000000000\"}]")
+	assert.Contains(t, string(marshalJSON), "{\"Map\":{\"key\":\"String\",\"value\":{\"List\":\"Key\"}}}")
+
+	err = json.Unmarshal([]byte("{\"StoredContractByHash\":{\"hash\":\"28ce14c210c53735d43eafef7f4446fb51c0761075c553029a9eb30988a0caa1\",\"entry_point\":\"mint\",\"args\":[[\"ids\",{\"cl_type\":{\"List\":\"Foo\"},\"bytes\":\"00000000\"}]]}}"),
+		&storedContractByHash)
+	assert.Error(t, err)
+}
+
+func TestDeployUtil_SetNestedArg(t *testing.T) {
+	transfer := NewTransfer(big.NewInt(2500000000), dest, "", 1)
+
+	one := uint64(1)
+	err := transfer.SetArg("ids", types.CLValue{Type: types.CLTypeList, List: &[]types.CLValue{
+		{Type: types.CLTypeOption, Option: &types.CLValue{Type: types.CLTypeU64, U64: &one}},
+	}})
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	assert.Equal(t, "List<Option<U64>>", transfer.Transfer.Args.Args["ids"].CLType().String())
+
+	err = transfer.SetArg("none", types.CLValue{Type: types.CLTypeOption})
+	assert.Error(t, err)
+
+	optionType := types.OptionCLType(types.SimpleCLType(types.CLTypeString))
+	err = transfer.SetArg("none", types.CLValue{Type: types.CLTypeOption, TypeInfo: &optionType})
+	assert.NoError(t, err)
+	assert.Equal(t, "01000000000d0a", hex.EncodeToString(transfer.Transfer.Args.Args["none"].ToBytes()))
+}
+
 func TestDeployUtil_StoredContractByName(t *testing.T) {
 	var hash32 [32]byte
 
@@ -371,7 +445,7 @@ func TestDeployUtil_MarshalStoredContractByName(t *testing.T) {
 		return
 	}
 
-	assert.Equal(t, "{\"StoredContractByName\":{\"args\":[[\"amount\",{\"bytes\":\"0400f90295\",\"cl_type\":\"U512\"}]],\"entry_point\":\"test\",\"name\":\"test\"}}",
+	assert.Equal(t, "{\"StoredContractByName\":{\"name\":\"test\",\"entry_point\":\"test\",\"args\":[[\"amount\",{\"cl_type\":\"U512\",\"bytes\":\"0400f90295\"}]]}}",
 		string(marshalJSON))
 }
 
@@ -421,7 +495,7 @@ func TestDeployUtil_MarshalStoredVersionedContractByHash(t *testing.T) {
 		return
 	}
 
-	assert.Equal(t, "{\"StoredVersionedContractByHash\":{\"args\":[[\"amount\",{\"bytes\":\"0400f90295\",\"cl_type\":\"U512\"}]],\"entry_point\":\"test\",\"hash\":\"d995c93ac47e763433b5ec973cac464c7343d76d6bd47c936cf8ce5d83032061\",\"version\":\"None\"}}",
+	assert.Equal(t, "{\"StoredVersionedContractByHash\":{\"hash\":\"d995c93ac47e763433b5ec973cac464c7343d76d6bd47c936cf8ce5d83032061\",\"version\":null,\"entry_point\":\"test\",\"args\":[[\"amount\",{\"cl_type\":\"U512\",\"bytes\":\"0400f90295\"}]]}}",
 		string(marshalJSON))
 }
 
@@ -466,7 +540,7 @@ func TestDeployUtil_MarshalStoredVersionedContractByName(t *testing.T) {
 		return
 	}
 
-	assert.Equal(t, "{\"StoredVersionedContractByName\":{\"args\":[[\"amount\",{\"bytes\":\"0400f90295\",\"cl_type\":\"U512\"}]],\"entry_point\":\"test\",\"name\":\"test\",\"version\":\"5\"}}",
+	assert.Equal(t, "{\"StoredVersionedContractByName\":{\"name\":\"test\",\"version\":5,\"entry_point\":\"test\",\"args\":[[\"amount\",{\"cl_type\":\"U512\",\"bytes\":\"0400f90295\"}]]}}",
 		string(marshalJSON))
 }
 
@@ -522,7 +596,7 @@ func TestDeployUtil_MarshalStoredContractByHashPublicKey(t *testing.T) {
 		return
 	}
 
-	assert.Equal(t, "{\"StoredContractByHash\":{\"args\":[[\"delegator\",{\"bytes\":\"0203b24eb09b295d3122d7abd1aafccb2c899d5db159e73e1c8fc972f017c308a363\",\"cl_type\":\"PublicKey\"}],[\"validator\",{\"bytes\":\"012bac1d0ff9240ff0b7b06d555815640497861619ca12583ddef434885416e69b\",\"cl_type\":\"PublicKey\"}],[\"amount\",{\"bytes\":\"05e0e0c5f4a6\",\"cl_type\":\"U512\"}]],\"entry_point\":\"delegate\",\"hash\":\"ccb576d6ce6dec84a551e48f0d0b7af89ddba44c7390b690036257a04a3ae9ea\"}}",
+	assert.Equal(t, "{\"StoredContractByHash\":{\"hash\":\"ccb576d6ce6dec84a551e48f0d0b7af89ddba44c7390b690036257a04a3ae9ea\",\"entry_point\":\"delegate\",\"args\":[[\"delegator\",{\"cl_type\":\"PublicKey\",\"bytes\":\"0203b24eb09b295d3122d7abd1aafccb2c899d5db159e73e1c8fc972f017c308a363\"}],[\"validator\",{\"cl_type\":\"PublicKey\",\"bytes\":\"012bac1d0ff9240ff0b7b06d555815640497861619ca12583ddef434885416e69b\"}],[\"amount\",{\"cl_type\":\"U512\",\"bytes\":\"05e0e0c5f4a6\"}]]}}",
 		string(marshalJSON))
 }
 
@@ -648,7 +722,7 @@ func TestDeployUtil_ValidateDeploy(t *testing.T) {
 		TransferId: 10,
 	}, big.NewInt(3), big.NewInt(1), "casper-test", "")
 
-	assert.True(t, deploy.ValidateDeploy())
+	assert.NoError(t, deploy.ValidateDeploy())
 
 	deployInJSON := "{\"hash\":\"48b33972cdc075d82363279640490b64bcac26cd540c8cf16da688d400c86b66\",\"header\":{\"account\":\"01d995c93ac47e763433b5ec973cac464c7343d76d6bd47c936cf8ce5d83032061\",\"timestamp\":\"2021-09-13T17:51:59.181Z\",\"ttl\":\"30m0s\",\"gas_price\":1,\"body_hash\":\"f9608668e24e68cad0c930016e1885d1d82fdb655b254130c32b586c4443af37\",\"dependencies\":[],\"chain_name\":\"casper-test\"},\"payment\":{\"ModuleBytes\":{\"args\":[[\"amount\",{\"bytes\":\"021027\",\"cl_type\":\"U512\"}]],\"module_bytes\":\"\"}},\"session\":{\"Transfer\":{\"args\":[[\"amount\",{\"bytes\":\"0400f90295\",\"cl_type\":\"U512\"}],[\"target\",{\"bytes\":\"a6d3d9fb1044cf5db1b30ad3f8f2c2c69e48ae69ab8aae6f02d69b0d0faa9e3d\",\"cl_type\":{\"ByteArray\":32}}],[\"id\",{\"bytes\":\"010100000000000000\",\"cl_type\":{\"Option\":\"U64\"}}]]}},\"approvals\":[]}"
 
@@ -660,12 +734,12 @@ func TestDeployUtil_ValidateDeploy(t *testing.T) {
 		return
 	}
 
-	assert.True(t, deployResult.ValidateDeploy())
+	assert.NoError(t, deployResult.ValidateDeploy())
 
 	deployResult.Hash = []byte("1234567")
-	assert.False(t, deployResult.ValidateDeploy())
+	assert.Error(t, deployResult.ValidateDeploy())
 	deploy.Header.BodyHash = []byte("1234567")
-	assert.False(t, deploy.ValidateDeploy())
+	assert.Error(t, deploy.ValidateDeploy())
 }
 
 func TestDeployUtil_SignDeploy(t *testing.T) {
@@ -679,7 +753,7 @@ func TestDeployUtil_SignDeploy(t *testing.T) {
 		return
 	}
 
-	assert.True(t, deploy.ValidateDeploy())
+	assert.NoError(t, deploy.ValidateDeploy())
 
 	deploy.SignDeploy(sourceKeyPair)
 
@@ -687,3 +761,44 @@ func TestDeployUtil_SignDeploy(t *testing.T) {
 	assert.Equal(t, "d995c93ac47e763433b5ec973cac464c7343d76d6bd47c936cf8ce5d83032061", hex.EncodeToString(deploy.Approvals[0].Signer.PubKeyData))
 	assert.Equal(t, "4ffe34cf43a62f94181090a9e1bb52db207d37138c927d07d642b81267822f66333b2014fe59cc8aca97a3852b9e66eb2e761cceb4deed2b03776b99bdef0a09", hex.EncodeToString(deploy.Approvals[0].Signature.SignatureData))
 }
+
+func TestDeployUtil_ValidateApprovals(t *testing.T) {
+	deploy := NewTransferToUniqAddress(*source, UniqAddress{
+		PublicKey:  dest,
+		TransferId: 10,
+	}, big.NewInt(3), big.NewInt(1), "casper-test", "")
+
+	deploy.SignDeploy(sourceKeyPair)
+	assert.NoError(t, deploy.ValidateDeploy())
+
+	secpPrivateKey := secp256k1.GenPrivKey()
+	secpSignature, err := secpPrivateKey.Sign(deploy.Hash)
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	deploy.Approvals = append(deploy.Approvals, Approval{
+		Signer:    keypair.PublicKey{Tag: keypair.KeyTagSecp256k1, PubKeyData: secpPrivateKey.PubKey().Bytes()},
+		Signature: keypair.Signature{Tag: keypair.KeyTagSecp256k1, SignatureData: secpSignature},
+	})
+	assert.NoError(t, deploy.ValidateDeploy())
+
+	deploy.SignDeploy(sourceKeyPair)
+	assert.True(t, errors.Is(deploy.ValidateDeploy(), ErrDuplicateApproval))
+
+	deploy.Approvals = deploy.Approvals[:2]
+	tampered := append([]byte{}, secpSignature...)
+	tampered[10] ^= 0xff
+	deploy.Approvals[1].Signature.SignatureData = tampered
+	assert.True(t, errors.Is(deploy.ValidateDeploy(), ErrInvalidApprovalSignature))
+
+	deploy.Approvals[1].Signature = keypair.Signature{Tag: keypair.KeyTagEd25519, SignatureData: secpSignature}
+	assert.True(t, errors.Is(deploy.ValidateDeploy(), ErrInvalidApprovalSignature))
+
+	deploy.Approvals = deploy.Approvals[:1]
+	deploy.Hash = deploy.Hash[:10]
+	assert.True(t, errors.Is(deploy.ValidateDeploy(), ErrInvalidDeployHash))
+
+	deploy.Header.BodyHash = nil
+	assert.True(t, errors.Is(deploy.ValidateDeploy(), ErrInvalidBodyHash))
+}
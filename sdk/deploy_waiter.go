@@ -0,0 +1,176 @@
+package sdk
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"time"
+)
+
+const defaultWaitPollInterval = 5 * time.Second
+
+type DeployStatus byte
+
+const (
+	DeployStatusSucceeded DeployStatus = iota + 1
+	DeployStatusFailed
+	DeployStatusExpired
+)
+
+func (s DeployStatus) String() string {
+	switch s {
+	case DeployStatusSucceeded:
+		return "Succeeded"
+	case DeployStatusFailed:
+		return "Failed"
+	case DeployStatusExpired:
+		return "Expired"
+	}
+	return "Unknown"
+}
+
+// DeployOutcome is the final state of a deploy, BlockHash and Cost are not set for expired deploys
+type DeployOutcome struct {
+	Status       DeployStatus
+	DeployHash   string
+	BlockHash    string
+	Cost         big.Int
+	ErrorMessage string
+}
+
+type WaitForDeployOptions struct {
+	// PollInterval is the delay between info_get_deploy calls, 5 seconds if zero
+	PollInterval time.Duration
+	// EventStream, if set, is used to wait for DeployProcessed and DeployExpired events instead of polling
+	EventStream *EventStreamClient
+}
+
+func (c *RpcClient) WaitForDeploy(deployHash string, options WaitForDeployOptions) (DeployOutcome, error) {
+	return c.WaitForDeployContext(context.Background(), deployHash, options)
+}
+
+// WaitForDeployContext waits until the deploy is executed in a block or its ttl passed.
+// Not yet known deploys are waited for as well, so the ctx deadline bounds the wait for deploys that never reach the node
+func (c *RpcClient) WaitForDeployContext(ctx context.Context, deployHash string, options WaitForDeployOptions) (DeployOutcome, error) {
+	if options.EventStream != nil {
+		return c.waitForDeployEvent(ctx, deployHash, options.EventStream)
+	}
+
+	interval := options.PollInterval
+	if interval <= 0 {
+		interval = defaultWaitPollInterval
+	}
+
+	for {
+		outcome, expiry, err := c.checkDeploy(ctx, deployHash)
+		if err != nil || outcome != nil {
+			return deployOutcomeOrZero(outcome), err
+		}
+
+		if !expiry.IsZero() && time.Now().After(expiry) {
+			return DeployOutcome{Status: DeployStatusExpired, DeployHash: deployHash}, nil
+		}
+
+		if err := sleepContext(ctx, interval); err != nil {
+			return DeployOutcome{}, err
+		}
+	}
+}
+
+func (c *RpcClient) waitForDeployEvent(ctx context.Context, deployHash string, stream *EventStreamClient) (DeployOutcome, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	events, errs := stream.Subscribe(ctx, EventStreamMain, nil)
+
+	// the deploy may have been processed before the subscription started
+	outcome, expiry, err := c.checkDeploy(ctx, deployHash)
+	if err != nil || outcome != nil {
+		return deployOutcomeOrZero(outcome), err
+	}
+
+	var expired <-chan time.Time
+	if !expiry.IsZero() {
+		timer := time.NewTimer(time.Until(expiry))
+		defer timer.Stop()
+		expired = timer.C
+	}
+
+	for {
+		select {
+		case event, ok := <-events:
+			if !ok {
+				return DeployOutcome{}, <-errs
+			}
+
+			switch {
+			case event.DeployProcessed != nil && event.DeployProcessed.DeployHash == deployHash:
+				return newDeployOutcome(deployHash, event.DeployProcessed.BlockHash, event.DeployProcessed.ExecutionResult)
+			case event.DeployExpired != nil && event.DeployExpired.DeployHash == deployHash:
+				return DeployOutcome{Status: DeployStatusExpired, DeployHash: deployHash}, nil
+			}
+		case <-expired:
+			outcome, _, err := c.checkDeploy(ctx, deployHash)
+			if err != nil || outcome != nil {
+				return deployOutcomeOrZero(outcome), err
+			}
+			return DeployOutcome{Status: DeployStatusExpired, DeployHash: deployHash}, nil
+		}
+	}
+}
+
+// checkDeploy returns the outcome if the deploy was executed, otherwise the time it expires if the deploy is known
+func (c *RpcClient) checkDeploy(ctx context.Context, deployHash string) (*DeployOutcome, time.Time, error) {
+	result, err := c.GetDeployContext(ctx, deployHash)
+	if errors.Is(err, ErrNoSuchDeploy) {
+		return nil, time.Time{}, nil
+	}
+	if err != nil {
+		return nil, time.Time{}, err
+	}
+
+	if len(result.ExecutionResults) > 0 {
+		executionResult := result.ExecutionResults[0]
+		outcome, err := newDeployOutcome(deployHash, executionResult.BlockHash, executionResult.Result)
+		if err != nil {
+			return nil, time.Time{}, err
+		}
+		return &outcome, time.Time{}, nil
+	}
+
+	ttl, err := parseHumanDuration(result.Deploy.Header.TTL)
+	if err != nil {
+		return nil, time.Time{}, fmt.Errorf("failed to parse deploy ttl: %w", err)
+	}
+
+	return nil, result.Deploy.Header.Timestamp.Add(ttl), nil
+}
+
+func newDeployOutcome(deployHash, blockHash string, result ExecutionResult) (DeployOutcome, error) {
+	outcome := DeployOutcome{
+		Status:     DeployStatusSucceeded,
+		DeployHash: deployHash,
+		BlockHash:  blockHash,
+	}
+
+	cost := result.Success.Cost
+	if result.Failure != nil {
+		outcome.Status = DeployStatusFailed
+		outcome.ErrorMessage = result.Failure.ErrorMessage
+		cost = result.Failure.Cost
+	}
+
+	if _, ok := outcome.Cost.SetString(cost, 10); !ok {
+		return DeployOutcome{}, fmt.Errorf("invalid deploy cost: %q", cost)
+	}
+
+	return outcome, nil
+}
+
+func deployOutcomeOrZero(outcome *DeployOutcome) DeployOutcome {
+	if outcome == nil {
+		return DeployOutcome{}
+	}
+	return *outcome
+}
@@ -0,0 +1,138 @@
+package sdk
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const testWaitDeployHash = "48b33972cdc075d82363279640490b64bcac26cd540c8cf16da688d400c86b66"
+
+func testPendingDeployResult(timestamp time.Time, ttl string) string {
+	return fmt.Sprintf(`{"jsonrpc":"2.0","id":"1","result":{"deploy":{"hash":"%s","header":{"timestamp":"%s","ttl":"%s"},"approvals":[]},"execution_results":[]}}`,
+		testWaitDeployHash, timestamp.UTC().Format(time.RFC3339Nano), ttl)
+}
+
+func TestParseHumanDuration(t *testing.T) {
+	cases := map[string]time.Duration{
+		"30m":       30 * time.Minute,
+		"1day":      24 * time.Hour,
+		"1h 30m":    90 * time.Minute,
+		"2h15m":     135 * time.Minute,
+		"500ms":     500 * time.Millisecond,
+		"1week 1s":  7*24*time.Hour + time.Second,
+		"2days 12h": 60 * time.Hour,
+	}
+
+	for value, expected := range cases {
+		parsed, err := parseHumanDuration(value)
+		if assert.NoError(t, err, value) {
+			assert.Equal(t, expected, parsed, value)
+		}
+	}
+
+	for _, value := range []string{"", "m", "30", "30 parsecs"} {
+		_, err := parseHumanDuration(value)
+		assert.Error(t, err, value)
+	}
+}
+
+func TestRpcClient_WaitForDeploySuccess(t *testing.T) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch atomic.AddInt32(&calls, 1) {
+		case 1:
+			w.Write([]byte(`{"jsonrpc":"2.0","id":"1","error":{"code":-32000,"message":"deploy not known"}}`))
+		case 2:
+			w.Write([]byte(testPendingDeployResult(time.Now(), "30m")))
+		default:
+			w.Write([]byte(`{"jsonrpc":"2.0","id":"1","result":{"deploy":{"hash":"` + testWaitDeployHash + `"},"execution_results":[{"block_hash":"5ab0a6e8","result":{"Success":{"effect":{},"transfers":[],"cost":"100000000"}}}]}}`))
+		}
+	}))
+	defer server.Close()
+
+	outcome, err := NewRpcClient(server.URL).WaitForDeploy(testWaitDeployHash, WaitForDeployOptions{PollInterval: time.Millisecond})
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	assert.Equal(t, DeployStatusSucceeded, outcome.Status)
+	assert.Equal(t, "5ab0a6e8", outcome.BlockHash)
+	assert.Equal(t, "100000000", outcome.Cost.String())
+	assert.Equal(t, int32(3), atomic.LoadInt32(&calls))
+}
+
+func TestRpcClient_WaitForDeployFailure(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"jsonrpc":"2.0","id":"1","result":{"deploy":{"hash":"` + testWaitDeployHash + `"},"execution_results":[{"block_hash":"5ab0a6e8","result":{"Failure":{"effect":{},"transfers":[],"cost":"1000","error_message":"User error: 1"}}}]}}`))
+	}))
+	defer server.Close()
+
+	outcome, err := NewRpcClient(server.URL).WaitForDeploy(testWaitDeployHash, WaitForDeployOptions{PollInterval: time.Millisecond})
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	assert.Equal(t, DeployStatusFailed, outcome.Status)
+	assert.Equal(t, "User error: 1", outcome.ErrorMessage)
+	assert.Equal(t, "1000", outcome.Cost.String())
+}
+
+func TestRpcClient_WaitForDeployExpired(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(testPendingDeployResult(time.Now().Add(-time.Hour), "30m")))
+	}))
+	defer server.Close()
+
+	outcome, err := NewRpcClient(server.URL).WaitForDeploy(testWaitDeployHash, WaitForDeployOptions{PollInterval: time.Millisecond})
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	assert.Equal(t, DeployStatusExpired, outcome.Status)
+	assert.Equal(t, testWaitDeployHash, outcome.DeployHash)
+}
+
+func TestRpcClient_WaitForDeployDeadline(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(testPendingDeployResult(time.Now(), "30m")))
+	}))
+	defer server.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	_, err := NewRpcClient(server.URL).WaitForDeployContext(ctx, testWaitDeployHash, WaitForDeployOptions{PollInterval: time.Millisecond})
+	assert.True(t, errors.Is(err, context.DeadlineExceeded))
+}
+
+func TestRpcClient_WaitForDeployEventStream(t *testing.T) {
+	rpcServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(testPendingDeployResult(time.Now(), "30m")))
+	}))
+	defer rpcServer.Close()
+
+	sseServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, "%s\n\n", testApiVersionEvent)
+		fmt.Fprintf(w, "data:{\"DeployProcessed\":{\"deploy_hash\":\"%s\",\"block_hash\":\"5ab0a6e8\",\"execution_result\":{\"Failure\":{\"transfers\":[],\"cost\":\"1000\",\"error_message\":\"Out of gas error\"}}}}\nid:7\n\n", testWaitDeployHash)
+		w.(http.Flusher).Flush()
+		<-r.Context().Done()
+	}))
+	defer sseServer.Close()
+
+	outcome, err := NewRpcClient(rpcServer.URL).WaitForDeploy(testWaitDeployHash, WaitForDeployOptions{EventStream: NewEventStreamClient(sseServer.URL)})
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	assert.Equal(t, DeployStatusFailed, outcome.Status)
+	assert.Equal(t, "5ab0a6e8", outcome.BlockHash)
+	assert.Equal(t, "Out of gas error", outcome.ErrorMessage)
+}
@@ -0,0 +1,212 @@
+package sdk
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+)
+
+// EraEnd is the report of a switch block about the era it ends
+type EraEnd struct {
+	EraReport               EraReport                `json:"era_report"`
+	NextEraValidatorWeights []NextEraValidatorWeight `json:"next_era_validator_weights"`
+}
+
+type EraReport struct {
+	Equivocators       []string    `json:"equivocators"`
+	Rewards            []EraReward `json:"rewards"`
+	InactiveValidators []string    `json:"inactive_validators"`
+}
+
+type EraReward struct {
+	Validator string `json:"validator"`
+	Amount    uint64 `json:"amount"`
+}
+
+type NextEraValidatorWeight struct {
+	Validator string `json:"validator"`
+	Weight    BigInt `json:"weight"`
+}
+
+type eraSummaryResult struct {
+	EraSummary *EraSummary `json:"era_summary"`
+}
+
+// EraSummary is the era info stored at the end of the era EraId
+type EraSummary struct {
+	BlockHash     string      `json:"block_hash"`
+	EraId         uint64      `json:"era_id"`
+	StoredValue   StoredValue `json:"stored_value"`
+	StateRootHash string      `json:"state_root_hash"`
+	MerkleProof   string      `json:"merkle_proof"`
+}
+
+type EraInfo struct {
+	SeigniorageAllocations []SeigniorageAllocation `json:"seigniorage_allocations"`
+}
+
+// SeigniorageAllocation is the reward of a validator or of a delegator, exactly one of the fields is set
+type SeigniorageAllocation struct {
+	Validator *ValidatorAllocation `json:"Validator,omitempty"`
+	Delegator *DelegatorAllocation `json:"Delegator,omitempty"`
+}
+
+type ValidatorAllocation struct {
+	ValidatorPublicKey string `json:"validator_public_key"`
+	Amount             BigInt `json:"amount"`
+}
+
+type DelegatorAllocation struct {
+	DelegatorPublicKey string `json:"delegator_public_key"`
+	ValidatorPublicKey string `json:"validator_public_key"`
+	Amount             BigInt `json:"amount"`
+}
+
+func (c *RpcClient) GetEraInfoBySwitchBlock(block BlockIdentifier) (*EraSummary, error) {
+	return c.GetEraInfoBySwitchBlockContext(context.Background(), block)
+}
+
+// GetEraInfoBySwitchBlockContext returns the summary of the era ended by the block, nil if the block isn't a switch block
+func (c *RpcClient) GetEraInfoBySwitchBlockContext(ctx context.Context, block BlockIdentifier) (*EraSummary, error) {
+	return c.getEraSummary(ctx, "chain_get_era_info_by_switch_block", block)
+}
+
+func (c *RpcClient) GetEraSummary(block BlockIdentifier) (EraSummary, error) {
+	return c.GetEraSummaryContext(context.Background(), block)
+}
+
+// GetEraSummaryContext returns the summary of the latest era finished before the given block, available on nodes since 1.5
+func (c *RpcClient) GetEraSummaryContext(ctx context.Context, block BlockIdentifier) (EraSummary, error) {
+	summary, err := c.getEraSummary(ctx, "chain_get_era_summary", block)
+	if err != nil {
+		return EraSummary{}, err
+	}
+	if summary == nil {
+		return EraSummary{}, errors.New("failed to get result: missing era summary")
+	}
+
+	return *summary, nil
+}
+
+func (c *RpcClient) getEraSummary(ctx context.Context, method string, block BlockIdentifier) (*EraSummary, error) {
+	// like for state_get_auction_info the params are left out for the latest block
+	var params interface{}
+	if block.Hash != "" || block.Height != nil {
+		params = blockParams{block}
+	}
+
+	resp, err := c.rpcCall(ctx, method, params)
+	if err != nil {
+		return nil, err
+	}
+
+	var result eraSummaryResult
+	err = json.Unmarshal(resp.Result, &result)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get result: %w", err)
+	}
+
+	return result.EraSummary, nil
+}
+
+func (c *RpcClient) GetRewards(publicKey string, fromEra, toEra uint64) (*big.Int, error) {
+	return c.GetRewardsContext(context.Background(), publicKey, fromEra, toEra)
+}
+
+// GetRewardsContext sums the seigniorage rewards of the hex encoded public key, as validator or as delegator, in the eras fromEra to toEra.
+// The switch block of each era is found by a binary search over the block heights, so each era costs about log2 of the
+// chain height calls instead of one call per block. Eras which haven't ended yet are left out
+func (c *RpcClient) GetRewardsContext(ctx context.Context, publicKey string, fromEra, toEra uint64) (*big.Int, error) {
+	if fromEra > toEra {
+		return nil, fmt.Errorf("invalid era range %d to %d", fromEra, toEra)
+	}
+
+	latest, err := c.GetLatestBlockContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	total := new(big.Int)
+	if latest.Header.EraID == 0 || fromEra >= latest.Header.EraID {
+		return total, nil
+	}
+	if toEra >= latest.Header.EraID {
+		toEra = latest.Header.EraID - 1
+	}
+
+	// the switch block of an era is below the one of the following era
+	maxHeight := latest.Header.Height
+	for era := toEra; ; era-- {
+		block, err := c.lastBlockOfEra(ctx, era, maxHeight)
+		if err != nil {
+			return nil, err
+		}
+		if block.Header.EraID != era || block.Header.EraEnd == nil {
+			return nil, fmt.Errorf("missing switch block of era %d", era)
+		}
+
+		summary, err := c.GetEraInfoBySwitchBlockContext(ctx, BlockByHash(block.Hash))
+		if err != nil {
+			return nil, err
+		}
+		if summary == nil {
+			return nil, fmt.Errorf("missing era summary of switch block %s", block.Hash)
+		}
+		total.Add(total, summary.Rewards(publicKey))
+
+		if era == fromEra || block.Header.Height == 0 {
+			break
+		}
+		maxHeight = block.Header.Height - 1
+	}
+
+	return total, nil
+}
+
+// lastBlockOfEra binary searches the highest block of at most maxHeight whose era is at most era
+func (c *RpcClient) lastBlockOfEra(ctx context.Context, era, maxHeight uint64) (BlockResponse, error) {
+	var found *BlockResponse
+	low, high := uint64(0), maxHeight
+
+	for low < high {
+		middle := low + (high-low+1)/2
+		block, err := c.GetBlockByHeightContext(ctx, middle)
+		if err != nil {
+			return BlockResponse{}, err
+		}
+
+		if block.Header.EraID <= era {
+			low = middle
+			found = &block
+		} else {
+			high = middle - 1
+		}
+	}
+
+	if found != nil && found.Header.Height == low {
+		return *found, nil
+	}
+	return c.GetBlockByHeightContext(ctx, low)
+}
+
+// Rewards returns the seigniorage rewards of the hex encoded public key in the era, as validator and as delegator
+func (e EraSummary) Rewards(publicKey string) *big.Int {
+	total := new(big.Int)
+	if e.StoredValue.EraInfo == nil {
+		return total
+	}
+
+	for _, allocation := range e.StoredValue.EraInfo.SeigniorageAllocations {
+		switch {
+		case allocation.Validator != nil && strings.EqualFold(allocation.Validator.ValidatorPublicKey, publicKey):
+			total.Add(total, &allocation.Validator.Amount.Int)
+		case allocation.Delegator != nil && strings.EqualFold(allocation.Delegator.DelegatorPublicKey, publicKey):
+			total.Add(total, &allocation.Delegator.Amount.Int)
+		}
+	}
+
+	return total
+}
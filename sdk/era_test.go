@@ -0,0 +1,175 @@
+package sdk
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const testEraEnd = `{"era_report":{"equivocators":[],"rewards":[{"validator":"` + testValidator + `","amount":1000}],"inactive_validators":[]},"next_era_validator_weights":[{"validator":"` + testValidator + `","weight":"1500000000000000000"}]}`
+
+func testEraSummary(eraId int, blockHash string, validatorReward, delegatorReward int) string {
+	return fmt.Sprintf(`{"block_hash":"%s","era_id":%d,"stored_value":{"EraInfo":{"seigniorage_allocations":[
+		{"Validator":{"validator_public_key":"%s","amount":"%d"}},
+		{"Delegator":{"delegator_public_key":"%s","validator_public_key":"%s","amount":"%d"}}
+	]}},"state_root_hash":"c0eb","merkle_proof":"01000000"}`, blockHash, eraId, testValidator, validatorReward, testDelegator, testValidator, delegatorReward)
+}
+
+func TestRpcClient_GetEraInfoBySwitchBlock(t *testing.T) {
+	var requests []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var request struct {
+			Method string          `json:"method"`
+			Params json.RawMessage `json:"params"`
+		}
+		json.NewDecoder(r.Body).Decode(&request)
+		requests = append(requests, request.Method+" "+string(request.Params))
+
+		switch string(request.Params) {
+		case `{"block_identifier":{"Hash":"0a"}}`:
+			w.Write([]byte(`{"jsonrpc":"2.0","id":"1","result":{"api_version":"1.4.3","era_summary":null}}`))
+		default:
+			w.Write([]byte(`{"jsonrpc":"2.0","id":"1","result":{"api_version":"1.4.3","era_summary":` + testEraSummary(41, "0b", 2000, 500) + `}}`))
+		}
+	}))
+	defer server.Close()
+
+	rpcClient := NewRpcClient(server.URL)
+
+	summary, err := rpcClient.GetEraInfoBySwitchBlock(BlockByHash("0b"))
+	if !assert.NoError(t, err) || !assert.NotNil(t, summary) {
+		return
+	}
+	assert.Equal(t, uint64(41), summary.EraId)
+	assert.Len(t, summary.StoredValue.EraInfo.SeigniorageAllocations, 2)
+	assert.Equal(t, "500", summary.StoredValue.EraInfo.SeigniorageAllocations[1].Delegator.Amount.String())
+	assert.Equal(t, "2000", summary.Rewards(testValidator).String())
+	assert.Equal(t, "500", summary.Rewards(testDelegator).String())
+	assert.Equal(t, "0", summary.Rewards("01ff").String())
+
+	summary, err = rpcClient.GetEraInfoBySwitchBlock(BlockByHash("0a"))
+	assert.NoError(t, err)
+	assert.Nil(t, summary)
+
+	latest, err := rpcClient.GetEraSummary(BlockIdentifier{})
+	assert.NoError(t, err)
+	assert.Equal(t, "0b", latest.BlockHash)
+
+	assert.Equal(t, []string{
+		`chain_get_era_info_by_switch_block {"block_identifier":{"Hash":"0b"}}`,
+		`chain_get_era_info_by_switch_block {"block_identifier":{"Hash":"0a"}}`,
+		`chain_get_era_summary null`,
+	}, requests)
+}
+
+func TestRpcClient_GetRewards(t *testing.T) {
+	// blocks 1, 3 and 4 are the switch blocks of the eras 0, 1 and 2, block 5 is in the running era 3
+	eras := []int{0, 0, 1, 1, 2, 3}
+	switchBlocks := map[int]bool{1: true, 3: true, 4: true}
+
+	block := func(height int) string {
+		eraEnd := "null"
+		if switchBlocks[height] {
+			eraEnd = testEraEnd
+		}
+		return fmt.Sprintf(`{"block":{"hash":"%02x","header":{"parent_hash":"","state_root_hash":"","body_hash":"","random_bit":false,"accumulated_seed":"","era_end":%s,"timestamp":"2021-11-10T10:00:00.000Z","era_id":%d,"height":%d,"protocol_version":"1.4.3"},"body":{"proposer":"","deploy_hashes":[],"transfer_hashes":[]},"proofs":[]}}`, height, eraEnd, eras[height], height)
+	}
+
+	var blockRequests int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var request struct {
+			Method string `json:"method"`
+			Params struct {
+				BlockIdentifier struct {
+					Hash   string
+					Height *int
+				} `json:"block_identifier"`
+			} `json:"params"`
+		}
+		json.NewDecoder(r.Body).Decode(&request)
+
+		var result string
+		switch request.Method {
+		case "chain_get_block":
+			blockRequests++
+			height := len(eras) - 1
+			if request.Params.BlockIdentifier.Height != nil {
+				height = *request.Params.BlockIdentifier.Height
+			}
+			result = block(height)
+		case "chain_get_era_info_by_switch_block":
+			var height int
+			fmt.Sscanf(request.Params.BlockIdentifier.Hash, "%x", &height)
+			result = `{"era_summary":` + testEraSummary(eras[height], request.Params.BlockIdentifier.Hash, 1000*(height+1), 10*(height+1)) + `}`
+		}
+		w.Write([]byte(`{"jsonrpc":"2.0","id":"1","result":` + result + `}`))
+	}))
+	defer server.Close()
+
+	rpcClient := NewRpcClient(server.URL)
+
+	rewards, err := rpcClient.GetRewards(testValidator, 1, 2)
+	assert.NoError(t, err)
+	assert.Equal(t, "9000", rewards.String())
+	assert.NotZero(t, blockRequests)
+
+	rewards, err = rpcClient.GetRewards(testDelegator, 0, 5)
+	assert.NoError(t, err)
+	assert.Equal(t, "110", rewards.String())
+
+	_, err = rpcClient.GetRewards(testDelegator, 2, 1)
+	assert.Error(t, err)
+}
+
+func TestRpcClient_GetRewards_History(t *testing.T) {
+	// 200 ended eras of 1000 blocks each, the last block of an era is its switch block
+	const eraLength, latestHeight = 1000, 200*1000 + 10
+
+	var blockRequests, summaryRequests int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var request struct {
+			Method string `json:"method"`
+			Params struct {
+				BlockIdentifier struct {
+					Hash   string
+					Height *int
+				} `json:"block_identifier"`
+			} `json:"params"`
+		}
+		json.NewDecoder(r.Body).Decode(&request)
+
+		var result string
+		switch request.Method {
+		case "chain_get_block":
+			blockRequests++
+			height := latestHeight
+			if request.Params.BlockIdentifier.Height != nil {
+				height = *request.Params.BlockIdentifier.Height
+			}
+			eraEnd := "null"
+			if height%eraLength == eraLength-1 {
+				eraEnd = testEraEnd
+			}
+			result = fmt.Sprintf(`{"block":{"hash":"%x","header":{"era_end":%s,"timestamp":"2021-11-10T10:00:00.000Z","era_id":%d,"height":%d},"body":{"deploy_hashes":[],"transfer_hashes":[]},"proofs":[]}}`, height, eraEnd, height/eraLength, height)
+		case "chain_get_era_info_by_switch_block":
+			summaryRequests++
+			var height int
+			fmt.Sscanf(request.Params.BlockIdentifier.Hash, "%x", &height)
+			result = `{"era_summary":` + testEraSummary(height/eraLength, request.Params.BlockIdentifier.Hash, 1000, height/eraLength) + `}`
+		}
+		w.Write([]byte(`{"jsonrpc":"2.0","id":"1","result":` + result + `}`))
+	}))
+	defer server.Close()
+
+	rewards, err := NewRpcClient(server.URL).GetRewards(testDelegator, 10, 12)
+	assert.NoError(t, err)
+	assert.Equal(t, "33", rewards.String())
+
+	// the latest block and a binary search over the heights per era, not one call per block since era 10
+	assert.Equal(t, 3, summaryRequests)
+	assert.LessOrEqual(t, blockRequests, 1+3*18)
+}
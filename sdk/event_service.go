@@ -1,81 +1,85 @@
 package sdk
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
 	"net/http"
+	"strconv"
 )
 
 type BlockResult struct {
-	BlockHash	string		`json:"block_hash"`
-	ParentHash	string		`json:"parent_hash"`
-	TimeStamp	string		`json:"time_stamp"`
-	Eraid		int			`json:"eraid"`
-	Proposer	string		`json:"proposer"`
-	State		string		`json:"state"`
-	DeployCount	int			`json:"deploy_count"`
-	Height		uint64		`json:"height"`
-	Deploys		[]string	`json:"deploys"`
+	BlockHash   string   `json:"block_hash"`
+	ParentHash  string   `json:"parent_hash"`
+	TimeStamp   string   `json:"time_stamp"`
+	Eraid       int      `json:"eraid"`
+	Proposer    string   `json:"proposer"`
+	State       string   `json:"state"`
+	DeployCount int      `json:"deploy_count"`
+	Height      uint64   `json:"height"`
+	Deploys     []string `json:"deploys"`
 }
 
 type Page struct {
-	Number	int		`json:"number"`
-	Url		string	`json:"url"`
+	Number int    `json:"number"`
+	Url    string `json:"url"`
 }
 
 type BlocksResult struct {
-	Data		[]BlockResult	`json:"data"`
-	PageCount	int				`json:"page_count"`
-	ItemCount	int				`json:"item_count"`
-	Pages 		[]Page			`json:"pages"`
+	Data      []BlockResult `json:"data"`
+	PageCount int           `json:"page_count"`
+	ItemCount int           `json:"item_count"`
+	Pages     []Page        `json:"pages"`
 }
 
 type DeployRes struct {
-	DeployHash		string	`json:"deploy_hash"`
-	State			string	`json:"state"`
-	Cost 			int		`json:"cost"`
-	ErrorMessage	string	`json:"error_message"`
-	Account			string	`json:"account"`
-	BlockHash		string	`json:"block_hash"`
+	DeployHash   string `json:"deploy_hash"`
+	State        string `json:"state"`
+	Cost         int    `json:"cost"`
+	ErrorMessage string `json:"error_message"`
+	Account      string `json:"account"`
+	BlockHash    string `json:"block_hash"`
 }
 
 type DeployHash struct {
-	BlockHash		string	`json:"block_hash"`
-	DeployHash		string	`json:"deploy_hash"`
-	State 			string	`json:"state"`
-	Cost 			int		`json:"cost"`
-	ErrorMessage	string	`json:"error_message"`
+	BlockHash    string `json:"block_hash"`
+	DeployHash   string `json:"deploy_hash"`
+	State        string `json:"state"`
+	Cost         int    `json:"cost"`
+	ErrorMessage string `json:"error_message"`
 }
 
 type AccountDeploy struct {
-	DeployHash		string	`json:"deploy_hash"`
-	Account 		string	`json:"account"`
-	State			string	`json:"state"`
-	Cost			int		`json:"cost"`
-	ErrorMessage	string	`json:"error_message"`
-	BlockHash		string	`json:"block_hash"`
+	DeployHash   string `json:"deploy_hash"`
+	Account      string `json:"account"`
+	State        string `json:"state"`
+	Cost         int    `json:"cost"`
+	ErrorMessage string `json:"error_message"`
+	BlockHash    string `json:"block_hash"`
 }
 
 type AccountDeploysResult struct {
-	Data		[]AccountDeploy	`json:"data"`
-	PageCount	int				`json:"page_count"`
-	ItemCount	int				`json:"item_count"`
-	Pages		[]Page			`json:"pages"`
+	Data      []AccountDeploy `json:"data"`
+	PageCount int             `json:"page_count"`
+	ItemCount int             `json:"item_count"`
+	Pages     []Page          `json:"pages"`
 }
 
 type TransferResult struct {
-	DeployHash	string	`json:"deploy_hash"`
-	SourcePurse	string	`json:"source_purse"`
-	TargetPurse	string	`json:"target_purse"`
-	Amount 		string	`json:"amount"`
-	Id			string	`json:"id"`
-	FromAccount	string	`json:"from_account"`
-	ToAccount	string	`json:"to_account"`
+	DeployHash  string `json:"deploy_hash"`
+	SourcePurse string `json:"source_purse"`
+	TargetPurse string `json:"target_purse"`
+	Amount      string `json:"amount"`
+	Id          string `json:"id"`
+	FromAccount string `json:"from_account"`
+	ToAccount   string `json:"to_account"`
 }
 
 type EventService struct {
-	Url	string
+	Url string
+	// HttpClient is used for requests, http.DefaultClient if nil
+	HttpClient *http.Client
 }
 
 func NewEventService(url string) *EventService {
@@ -84,99 +88,160 @@ func NewEventService(url string) *EventService {
 	}
 }
 
-func (e EventService) GetResponseData(endpoint string) ([]byte,error) {
+func (e EventService) GetResponseData(endpoint string) ([]byte, error) {
+	return e.GetResponseDataContext(context.Background(), endpoint)
+}
+
+func (e EventService) GetResponseDataContext(ctx context.Context, endpoint string) ([]byte, error) {
 	get := fmt.Sprintf("%s%s", e.Url, endpoint)
 
-	resp, err := http.Get(get)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, get, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	httpClient := e.HttpClient
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+
+	resp, err := httpClient.Do(req)
 	if err != nil {
-		fmt.Errorf("failed to get data: %w", err)
+		return nil, &HttpError{Err: err}
 	}
 	defer resp.Body.Close()
 
 	b, err := ioutil.ReadAll(resp.Body)
 	if err != nil {
-		fmt.Errorf("failed to get response body: %w", err)
+		return nil, &HttpError{StatusCode: resp.StatusCode, Err: err}
 	}
 
 	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
-		fmt.Errorf("request failed, status code - %d, response - %s", resp.StatusCode, string(b))
+		return nil, &HttpError{StatusCode: resp.StatusCode, Body: string(b)}
 	}
 	return b, nil
 }
 
 func (e EventService) GetBlocks(page int, count int) (BlocksResult, error) {
+	return e.GetBlocksContext(context.Background(), page, count)
+}
+
+func (e EventService) GetBlocksContext(ctx context.Context, page int, count int) (BlocksResult, error) {
 	endpoint := fmt.Sprintf("/blocks?page=%d&limit=%d", page, count)
-	resp, err := e.GetResponseData(endpoint)
 
+	var blocks BlocksResult
+	err := e.getAndParse(ctx, endpoint, &blocks)
 	if err != nil {
 		return BlocksResult{}, err
 	}
 
-	var blocks BlocksResult
-	parseResponseBody(resp, blocks)
-
 	return blocks, nil
 }
 
-func (e EventService) GetDeployByHash(deployHash string) (DeployResult, error) {
+// GetDeploy returns the deploy with its execution state as tracked by the event service
+func (e EventService) GetDeploy(deployHash string) (DeployRes, error) {
+	return e.GetDeployContext(context.Background(), deployHash)
+}
+
+func (e EventService) GetDeployContext(ctx context.Context, deployHash string) (DeployRes, error) {
 	endpoint := fmt.Sprintf("/deploy/%s", deployHash)
-	resp, err := e.GetResponseData(endpoint)
 
+	var deploy DeployRes
+	err := e.getAndParse(ctx, endpoint, &deploy)
+	if err != nil {
+		return DeployRes{}, err
+	}
+
+	return deploy, nil
+}
+
+// Deprecated: GetDeployByHash fills only the hash, the account and the execution result of the rpc DeployResult,
+// use GetDeploy instead
+func (e EventService) GetDeployByHash(deployHash string) (DeployResult, error) {
+	deploy, err := e.GetDeploy(deployHash)
 	if err != nil {
 		return DeployResult{}, err
 	}
 
-	var deploy DeployResult
-	parseResponseBody(resp, deploy)
+	result := DeployResult{
+		Deploy: JsonDeploy{Hash: deploy.DeployHash, Header: JsonDeployHeader{Account: deploy.Account}},
+	}
+	if deploy.BlockHash != "" {
+		cost := strconv.Itoa(deploy.Cost)
+		execution := JsonExecutionResult{BlockHash: deploy.BlockHash}
+		if deploy.ErrorMessage != "" {
+			execution.Result.Failure = &FailureExecutionResult{Cost: cost, ErrorMessage: deploy.ErrorMessage}
+			execution.Result.ErrorMessage = &deploy.ErrorMessage
+		} else {
+			execution.Result.Success.Cost = cost
+		}
+		result.ExecutionResults = []JsonExecutionResult{execution}
+	}
 
-	return deploy, nil
+	return result, nil
 }
 
 func (e EventService) GetBlockByHash(blockHash string) (BlockResult, error) {
+	return e.GetBlockByHashContext(context.Background(), blockHash)
+}
+
+func (e EventService) GetBlockByHashContext(ctx context.Context, blockHash string) (BlockResult, error) {
 	endpoint := fmt.Sprintf("/block/%s", blockHash)
-	resp, err := e.GetResponseData(endpoint)
 
+	var block BlockResult
+	err := e.getAndParse(ctx, endpoint, &block)
 	if err != nil {
 		return BlockResult{}, err
 	}
 
-	var block BlockResult
-	parseResponseBody(resp, block)
-
 	return block, nil
 }
 
 func (e EventService) GetAccountDeploy(accountHex string, page int, limit int) (AccountDeploysResult, error) {
+	return e.GetAccountDeployContext(context.Background(), accountHex, page, limit)
+}
+
+func (e EventService) GetAccountDeployContext(ctx context.Context, accountHex string, page int, limit int) (AccountDeploysResult, error) {
 	endpoint := fmt.Sprintf("/accountDeploys/%s?page=%d&limit=%d", accountHex, page, limit)
-	resp, err := e.GetResponseData(endpoint)
 
+	var accountDeploys AccountDeploysResult
+	err := e.getAndParse(ctx, endpoint, &accountDeploys)
 	if err != nil {
 		return AccountDeploysResult{}, err
 	}
 
-	var accountDeploys AccountDeploysResult
-	parseResponseBody(resp, accountDeploys)
-
 	return accountDeploys, nil
 }
 
 func (e EventService) GetTransfersByAccountHash(accountHash string) ([]TransferResult, error) {
+	return e.GetTransfersByAccountHashContext(context.Background(), accountHash)
+}
+
+func (e EventService) GetTransfersByAccountHashContext(ctx context.Context, accountHash string) ([]TransferResult, error) {
 	endpoint := fmt.Sprintf("/transfers/%s", accountHash)
-	resp, err := e.GetResponseData(endpoint)
 
+	var transfers []TransferResult
+	err := e.getAndParse(ctx, endpoint, &transfers)
 	if err != nil {
 		return []TransferResult{}, err
 	}
 
-	var transfers []TransferResult
-	parseResponseBody(resp, transfers)
-
 	return transfers, nil
 }
 
-func parseResponseBody(response []byte, dest interface{})  {
-	err := json.Unmarshal(response, &dest)
+func (e EventService) getAndParse(ctx context.Context, endpoint string, dest interface{}) error {
+	resp, err := e.GetResponseDataContext(ctx, endpoint)
 	if err != nil {
-		fmt.Errorf("failed to parse response body: %w", err)
+		return err
 	}
-}
\ No newline at end of file
+
+	return parseResponseBody(resp, dest)
+}
+
+func parseResponseBody(response []byte, dest interface{}) error {
+	err := json.Unmarshal(response, dest)
+	if err != nil {
+		return fmt.Errorf("failed to parse response body: %w", err)
+	}
+	return nil
+}
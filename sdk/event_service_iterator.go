@@ -0,0 +1,168 @@
+package sdk
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+)
+
+const defaultPageSize = 10
+
+// pageIterator walks the pages of an event service endpoint, fetching the next page when the current one is exhausted
+type pageIterator struct {
+	ctx      context.Context
+	pageSize int
+	page     int
+	index    int
+	length   int
+	done     bool
+	err      error
+	// fetch loads the given page and returns the number of items on it and whether it is the last one
+	fetch func(ctx context.Context, page int) (int, bool, error)
+}
+
+func newPageIterator(ctx context.Context, pageSize int) pageIterator {
+	if pageSize <= 0 {
+		pageSize = defaultPageSize
+	}
+
+	return pageIterator{
+		ctx:      ctx,
+		pageSize: pageSize,
+		index:    -1,
+	}
+}
+
+// Next advances to the next item, fetching the next page if needed.
+// It returns false when all pages are consumed, the context is done or a request failed, see Err
+func (it *pageIterator) Next() bool {
+	if it.err != nil {
+		return false
+	}
+
+	it.index++
+	for it.index >= it.length {
+		if it.done {
+			return false
+		}
+
+		if err := it.ctx.Err(); err != nil {
+			it.err = err
+			return false
+		}
+
+		length, last, err := it.fetch(it.ctx, it.page+1)
+		if err != nil {
+			it.err = fmt.Errorf("failed to fetch page %d: %w", it.page+1, err)
+			return false
+		}
+
+		it.page++
+		it.index = 0
+		it.length = length
+		it.done = last || length == 0
+	}
+
+	return true
+}
+
+// Err returns the error which stopped the iteration, nil if all pages were consumed
+func (it *pageIterator) Err() error {
+	return it.err
+}
+
+// Page returns the number of the page the current item belongs to
+func (it *pageIterator) Page() int {
+	return it.page
+}
+
+// BlockIterator iterates over all blocks of the event service
+type BlockIterator struct {
+	pageIterator
+	blocks []BlockResult
+}
+
+// IterateBlocks returns an iterator over all blocks, requesting pageSize blocks at a time
+func (e EventService) IterateBlocks(ctx context.Context, pageSize int) *BlockIterator {
+	it := &BlockIterator{pageIterator: newPageIterator(ctx, pageSize)}
+	it.fetch = func(ctx context.Context, page int) (int, bool, error) {
+		result, err := e.GetBlocksContext(ctx, page, it.pageSize)
+		if err != nil {
+			return 0, false, err
+		}
+
+		it.blocks = result.Data
+		return len(result.Data), page >= result.PageCount, nil
+	}
+
+	return it
+}
+
+// Block returns the current block, valid after Next returned true
+func (it *BlockIterator) Block() BlockResult {
+	return it.blocks[it.index]
+}
+
+// AccountDeployIterator iterates over the complete deploy history of an account
+type AccountDeployIterator struct {
+	pageIterator
+	deploys []AccountDeploy
+}
+
+// IterateAccountDeploys returns an iterator over all deploys of the account, requesting pageSize deploys at a time
+func (e EventService) IterateAccountDeploys(ctx context.Context, accountHex string, pageSize int) *AccountDeployIterator {
+	it := &AccountDeployIterator{pageIterator: newPageIterator(ctx, pageSize)}
+	it.fetch = func(ctx context.Context, page int) (int, bool, error) {
+		result, err := e.GetAccountDeployContext(ctx, accountHex, page, it.pageSize)
+		if err != nil {
+			return 0, false, err
+		}
+
+		it.deploys = result.Data
+		return len(result.Data), page >= result.PageCount, nil
+	}
+
+	return it
+}
+
+// Deploy returns the current deploy, valid after Next returned true
+func (it *AccountDeployIterator) Deploy() AccountDeploy {
+	return it.deploys[it.index]
+}
+
+// TransferIterator iterates over all transfers of an account
+type TransferIterator struct {
+	pageIterator
+	transfers []TransferResult
+}
+
+// IterateTransfers returns an iterator over all transfers of the account, requesting pageSize transfers at a time.
+// The transfers endpoint returns no page count, so the iteration ends with the first page holding less than pageSize transfers.
+// An endpoint ignoring the page and limit parameters returns the same transfers for every page, so the iteration also
+// ends with a page holding more than pageSize transfers or repeating the previous page
+func (e EventService) IterateTransfers(ctx context.Context, accountHash string, pageSize int) *TransferIterator {
+	it := &TransferIterator{pageIterator: newPageIterator(ctx, pageSize)}
+	it.fetch = func(ctx context.Context, page int) (int, bool, error) {
+		endpoint := fmt.Sprintf("/transfers/%s?page=%d&limit=%d", accountHash, page, it.pageSize)
+
+		var transfers []TransferResult
+		err := e.getAndParse(ctx, endpoint, &transfers)
+		if err != nil {
+			return 0, false, err
+		}
+
+		if page > 1 && reflect.DeepEqual(transfers, it.transfers) {
+			return 0, true, nil
+		}
+
+		it.transfers = transfers
+		return len(transfers), len(transfers) != it.pageSize, nil
+	}
+
+	return it
+}
+
+// Transfer returns the current transfer, valid after Next returned true
+func (it *TransferIterator) Transfer() TransferResult {
+	return it.transfers[it.index]
+}
@@ -0,0 +1,289 @@
+package sdk
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var eventServiceResponses = map[string]string{
+	"/blocks?page=1&limit=2":                  `{"data":[{"block_hash":"5ab0a6e8","parent_hash":"1ac4c5b2","time_stamp":"2021-09-13T17:51:59.181Z","eraid":10,"proposer":"01d995c9","state":"finalized","deploy_count":1,"height":1034,"deploys":["48b33972"]}],"page_count":5,"item_count":10,"pages":[{"number":1,"url":"/blocks?page=1&limit=2"}]}`,
+	"/deploy/48b33972":                        `{"deploy_hash":"48b33972","state":"processed","cost":100000,"error_message":"","account":"01d995c9","block_hash":"5ab0a6e8"}`,
+	"/block/5ab0a6e8":                         `{"block_hash":"5ab0a6e8","parent_hash":"1ac4c5b2","eraid":10,"proposer":"01d995c9","state":"finalized","deploy_count":1,"height":1034,"deploys":["48b33972"]}`,
+	"/accountDeploys/01d995c9?page=2&limit=1": `{"data":[{"deploy_hash":"48b33972","account":"01d995c9","state":"processed","cost":100000,"error_message":"","block_hash":"5ab0a6e8"}],"page_count":3,"item_count":3,"pages":[{"number":2,"url":"/accountDeploys/01d995c9?page=2&limit=1"}]}`,
+	"/transfers/a9efd010":                     `[{"deploy_hash":"48b33972","source_purse":"uref-1","target_purse":"uref-2","amount":"2500000000","id":"1","from_account":"a9efd010","to_account":"a6d3d9fb"}]`,
+}
+
+func newTestEventService(t *testing.T) (*EventService, func()) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		response, ok := eventServiceResponses[r.URL.RequestURI()]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte("not found"))
+			return
+		}
+		w.Write([]byte(response))
+	}))
+
+	return NewEventService(server.URL), server.Close
+}
+
+func TestEventService_GetBlocks(t *testing.T) {
+	service, closeServer := newTestEventService(t)
+	defer closeServer()
+
+	blocks, err := service.GetBlocks(1, 2)
+	if !assert.NoError(t, err) || !assert.Len(t, blocks.Data, 1) {
+		return
+	}
+
+	assert.Equal(t, "5ab0a6e8", blocks.Data[0].BlockHash)
+	assert.Equal(t, uint64(1034), blocks.Data[0].Height)
+	assert.Equal(t, []string{"48b33972"}, blocks.Data[0].Deploys)
+	assert.Equal(t, 5, blocks.PageCount)
+	assert.Equal(t, 10, blocks.ItemCount)
+	assert.Len(t, blocks.Pages, 1)
+}
+
+func TestEventService_GetDeploy(t *testing.T) {
+	service, closeServer := newTestEventService(t)
+	defer closeServer()
+
+	deploy, err := service.GetDeploy("48b33972")
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	assert.Equal(t, "48b33972", deploy.DeployHash)
+	assert.Equal(t, "processed", deploy.State)
+	assert.Equal(t, 100000, deploy.Cost)
+	assert.Equal(t, "5ab0a6e8", deploy.BlockHash)
+}
+
+func TestEventService_GetDeployByHash(t *testing.T) {
+	service, closeServer := newTestEventService(t)
+	defer closeServer()
+
+	deploy, err := service.GetDeployByHash("48b33972")
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	assert.Equal(t, "48b33972", deploy.Deploy.Hash)
+	assert.Equal(t, "01d995c9", deploy.Deploy.Header.Account)
+	if assert.Len(t, deploy.ExecutionResults, 1) {
+		assert.Equal(t, "5ab0a6e8", deploy.ExecutionResults[0].BlockHash)
+		assert.Equal(t, "100000", deploy.ExecutionResults[0].Result.Success.Cost)
+		assert.Nil(t, deploy.ExecutionResults[0].Result.Failure)
+	}
+}
+
+func TestEventService_GetBlockByHash(t *testing.T) {
+	service, closeServer := newTestEventService(t)
+	defer closeServer()
+
+	block, err := service.GetBlockByHash("5ab0a6e8")
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	assert.Equal(t, "1ac4c5b2", block.ParentHash)
+	assert.Equal(t, 10, block.Eraid)
+	assert.Equal(t, 1, block.DeployCount)
+}
+
+func TestEventService_GetAccountDeploy(t *testing.T) {
+	service, closeServer := newTestEventService(t)
+	defer closeServer()
+
+	deploys, err := service.GetAccountDeploy("01d995c9", 2, 1)
+	if !assert.NoError(t, err) || !assert.Len(t, deploys.Data, 1) {
+		return
+	}
+
+	assert.Equal(t, "48b33972", deploys.Data[0].DeployHash)
+	assert.Equal(t, 3, deploys.PageCount)
+}
+
+func TestEventService_GetTransfersByAccountHash(t *testing.T) {
+	service, closeServer := newTestEventService(t)
+	defer closeServer()
+
+	transfers, err := service.GetTransfersByAccountHash("a9efd010")
+	if !assert.NoError(t, err) || !assert.Len(t, transfers, 1) {
+		return
+	}
+
+	assert.Equal(t, "2500000000", transfers[0].Amount)
+	assert.Equal(t, "a6d3d9fb", transfers[0].ToAccount)
+}
+
+func TestEventService_Errors(t *testing.T) {
+	service, closeServer := newTestEventService(t)
+
+	_, err := service.GetDeploy("unknown")
+	var httpErr *HttpError
+	if assert.True(t, errors.As(err, &httpErr)) {
+		assert.Equal(t, http.StatusNotFound, httpErr.StatusCode)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Nanosecond)
+	defer cancel()
+	<-ctx.Done()
+	_, err = service.GetBlocksContext(ctx, 1, 2)
+	assert.True(t, errors.Is(err, context.DeadlineExceeded))
+
+	closeServer()
+	_, err = service.GetBlockByHash("5ab0a6e8")
+	assert.True(t, errors.As(err, &httpErr))
+}
+
+func TestEventService_InvalidBody(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("{not json"))
+	}))
+	defer server.Close()
+
+	_, err := NewEventService(server.URL).GetAccountDeploy("01d995c9", 1, 1)
+	assert.Error(t, err)
+}
+
+func newPagedEventService(t *testing.T, total int) (*EventService, *int32, func()) {
+	var requests int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+		limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+
+		items := make([]map[string]interface{}, 0)
+		for i := (page - 1) * limit; i < page*limit && i < total; i++ {
+			items = append(items, map[string]interface{}{"deploy_hash": strconv.Itoa(i), "block_hash": strconv.Itoa(i), "height": i})
+		}
+
+		if strings.HasPrefix(r.URL.Path, "/transfers/") {
+			json.NewEncoder(w).Encode(items)
+			return
+		}
+
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"data":       items,
+			"page_count": (total + limit - 1) / limit,
+			"item_count": total,
+		})
+	}))
+
+	return NewEventService(server.URL), &requests, server.Close
+}
+
+func TestEventService_IterateAccountDeploys(t *testing.T) {
+	service, requests, closeServer := newPagedEventService(t, 7)
+	defer closeServer()
+
+	it := service.IterateAccountDeploys(context.Background(), "01d995c9", 3)
+	hashes := make([]string, 0)
+	for it.Next() {
+		hashes = append(hashes, it.Deploy().DeployHash)
+	}
+
+	assert.NoError(t, it.Err())
+	assert.Equal(t, []string{"0", "1", "2", "3", "4", "5", "6"}, hashes)
+	assert.Equal(t, 3, it.Page())
+	assert.Equal(t, int32(3), atomic.LoadInt32(requests))
+}
+
+func TestEventService_IterateBlocks(t *testing.T) {
+	service, _, closeServer := newPagedEventService(t, 4)
+	defer closeServer()
+
+	it := service.IterateBlocks(context.Background(), 2)
+	heights := make([]uint64, 0)
+	for it.Next() {
+		heights = append(heights, it.Block().Height)
+	}
+
+	assert.NoError(t, it.Err())
+	assert.Equal(t, []uint64{0, 1, 2, 3}, heights)
+}
+
+func TestEventService_IterateTransfers(t *testing.T) {
+	service, requests, closeServer := newPagedEventService(t, 4)
+	defer closeServer()
+
+	it := service.IterateTransfers(context.Background(), "a9efd010", 2)
+	count := 0
+	for it.Next() {
+		assert.Equal(t, strconv.Itoa(count), it.Transfer().DeployHash)
+		count++
+	}
+
+	assert.NoError(t, it.Err())
+	assert.Equal(t, 4, count)
+	assert.Equal(t, int32(3), atomic.LoadInt32(requests))
+}
+
+func TestEventService_IterateTransfers_PagingIgnored(t *testing.T) {
+	for _, total := range []int{2, 5} {
+		var requests int32
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			atomic.AddInt32(&requests, 1)
+			items := make([]map[string]interface{}, 0)
+			for i := 0; i < total; i++ {
+				items = append(items, map[string]interface{}{"deploy_hash": strconv.Itoa(i)})
+			}
+			json.NewEncoder(w).Encode(items)
+		}))
+
+		it := NewEventService(server.URL).IterateTransfers(context.Background(), "a9efd010", 2)
+		count := 0
+		for it.Next() {
+			count++
+		}
+		server.Close()
+
+		assert.NoError(t, it.Err())
+		assert.Equal(t, total, count)
+		assert.LessOrEqual(t, atomic.LoadInt32(&requests), int32(2))
+	}
+}
+
+func TestEventService_IteratorCancel(t *testing.T) {
+	service, requests, closeServer := newPagedEventService(t, 10)
+	defer closeServer()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	it := service.IterateBlocks(ctx, 2)
+
+	count := 0
+	for it.Next() {
+		count++
+		if count == 3 {
+			cancel()
+		}
+	}
+
+	assert.Equal(t, 4, count)
+	assert.True(t, errors.Is(it.Err(), context.Canceled))
+	assert.Equal(t, int32(2), atomic.LoadInt32(requests))
+	assert.False(t, it.Next())
+}
+
+func TestEventService_IteratorError(t *testing.T) {
+	service, closeServer := newTestEventService(t)
+	defer closeServer()
+
+	it := service.IterateAccountDeploys(context.Background(), "unknown", 5)
+	assert.False(t, it.Next())
+
+	var httpErr *HttpError
+	assert.True(t, errors.As(it.Err(), &httpErr))
+}
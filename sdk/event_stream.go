@@ -0,0 +1,360 @@
+package sdk
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// EventStream is the path of a node sse endpoint
+type EventStream string
+
+const (
+	EventStreamMain    EventStream = "/events/main"
+	EventStreamDeploys EventStream = "/events/deploys"
+	EventStreamSigs    EventStream = "/events/sigs"
+)
+
+type EventType string
+
+const (
+	EventTypeApiVersion        EventType = "ApiVersion"
+	EventTypeBlockAdded        EventType = "BlockAdded"
+	EventTypeDeployAccepted    EventType = "DeployAccepted"
+	EventTypeDeployProcessed   EventType = "DeployProcessed"
+	EventTypeDeployExpired     EventType = "DeployExpired"
+	EventTypeFault             EventType = "Fault"
+	EventTypeStep              EventType = "Step"
+	EventTypeFinalitySignature EventType = "FinalitySignature"
+	EventTypeShutdown          EventType = "Shutdown"
+)
+
+// Event is a single event of a node event stream, only the field matching Type is set
+type Event struct {
+	// Id is the id sent by the node, ApiVersion events have no id
+	Id                uint64
+	Type              EventType
+	ApiVersion        *string
+	BlockAdded        *BlockAddedEvent
+	DeployAccepted    *Deploy
+	DeployProcessed   *DeployProcessedEvent
+	DeployExpired     *DeployExpiredEvent
+	Fault             *FaultEvent
+	Step              *StepEvent
+	FinalitySignature *FinalitySignatureEvent
+}
+
+type BlockAddedEvent struct {
+	BlockHash string        `json:"block_hash"`
+	Block     BlockResponse `json:"block"`
+}
+
+type DeployProcessedEvent struct {
+	DeployHash      string          `json:"deploy_hash"`
+	Account         string          `json:"account"`
+	Timestamp       time.Time       `json:"timestamp"`
+	TTL             string          `json:"ttl"`
+	Dependencies    []string        `json:"dependencies"`
+	BlockHash       string          `json:"block_hash"`
+	ExecutionResult ExecutionResult `json:"execution_result"`
+}
+
+type DeployExpiredEvent struct {
+	DeployHash string `json:"deploy_hash"`
+}
+
+type FaultEvent struct {
+	EraId     uint64    `json:"era_id"`
+	PublicKey string    `json:"public_key"`
+	Timestamp time.Time `json:"timestamp"`
+}
+
+type StepEvent struct {
+	EraId           uint64          `json:"era_id"`
+	ExecutionEffect json.RawMessage `json:"execution_effect"`
+}
+
+type FinalitySignatureEvent struct {
+	BlockHash string `json:"block_hash"`
+	EraId     uint64 `json:"era_id"`
+	Signature string `json:"signature"`
+	PublicKey string `json:"public_key"`
+}
+
+// ParseEvent decodes the data field of a node sse message
+func ParseEvent(data []byte) (Event, error) {
+	data = bytes.TrimSpace(data)
+
+	var shutdown string
+	if err := json.Unmarshal(data, &shutdown); err == nil {
+		if shutdown != string(EventTypeShutdown) {
+			return Event{}, fmt.Errorf("unknown event: %s", shutdown)
+		}
+		return Event{Type: EventTypeShutdown}, nil
+	}
+
+	var raw map[string]json.RawMessage
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return Event{}, fmt.Errorf("failed to parse event: %w", err)
+	}
+
+	if len(raw) != 1 {
+		return Event{}, errors.New("failed to parse event: expected exactly one event type")
+	}
+
+	var event Event
+	var dest interface{}
+
+	for key, payload := range raw {
+		event.Type = EventType(key)
+
+		switch event.Type {
+		case EventTypeApiVersion:
+			event.ApiVersion = new(string)
+			dest = event.ApiVersion
+		case EventTypeBlockAdded:
+			event.BlockAdded = new(BlockAddedEvent)
+			dest = event.BlockAdded
+		case EventTypeDeployAccepted:
+			event.DeployAccepted = new(Deploy)
+			dest = event.DeployAccepted
+		case EventTypeDeployProcessed:
+			event.DeployProcessed = new(DeployProcessedEvent)
+			dest = event.DeployProcessed
+		case EventTypeDeployExpired:
+			event.DeployExpired = new(DeployExpiredEvent)
+			dest = event.DeployExpired
+		case EventTypeFault:
+			event.Fault = new(FaultEvent)
+			dest = event.Fault
+		case EventTypeStep:
+			event.Step = new(StepEvent)
+			dest = event.Step
+		case EventTypeFinalitySignature:
+			event.FinalitySignature = new(FinalitySignatureEvent)
+			dest = event.FinalitySignature
+		default:
+			return Event{}, fmt.Errorf("unknown event type: %s", key)
+		}
+
+		if err := json.Unmarshal(payload, dest); err != nil {
+			return Event{}, fmt.Errorf("failed to parse %s event: %w", key, err)
+		}
+	}
+
+	return event, nil
+}
+
+// EventStreamClient subscribes to the sse endpoints of a node
+type EventStreamClient struct {
+	url            string
+	httpClient     *http.Client
+	reconnectDelay time.Duration
+	onError        func(error)
+}
+
+// EventStreamOption configures optional EventStreamClient settings
+type EventStreamOption func(*EventStreamClient)
+
+// WithStreamHTTPClient sets the http.Client used for connections, it should not have a timeout
+func WithStreamHTTPClient(httpClient *http.Client) EventStreamOption {
+	return func(c *EventStreamClient) {
+		c.httpClient = httpClient
+	}
+}
+
+// WithReconnectDelay sets the delay before reconnecting after the stream was interrupted
+func WithReconnectDelay(delay time.Duration) EventStreamOption {
+	return func(c *EventStreamClient) {
+		c.reconnectDelay = delay
+	}
+}
+
+// WithStreamErrorHandler sets a function that is called with every connection or parsing error the client recovers from
+func WithStreamErrorHandler(onError func(error)) EventStreamOption {
+	return func(c *EventStreamClient) {
+		c.onError = onError
+	}
+}
+
+// NewEventStreamClient creates a client for the node event server, url is its base address, e.g. http://127.0.0.1:9999
+func NewEventStreamClient(url string, opts ...EventStreamOption) *EventStreamClient {
+	c := &EventStreamClient{
+		url:            strings.TrimRight(url, "/"),
+		httpClient:     http.DefaultClient,
+		reconnectDelay: time.Second,
+	}
+
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	return c
+}
+
+// Listen delivers the events of stream to handler until ctx is done or handler returns an error.
+// Interrupted connections are reestablished, resuming with start_from after the last received event id.
+// If startFrom is not nil, the node replays its stored events beginning with that id
+func (c *EventStreamClient) Listen(ctx context.Context, stream EventStream, startFrom *uint64, handler func(Event) error) error {
+	var next *uint64
+	if startFrom != nil {
+		id := *startFrom
+		next = &id
+	}
+
+	for {
+		err := c.listen(ctx, stream, next, func(event Event) error {
+			if event.Type != EventTypeApiVersion && event.Type != EventTypeShutdown {
+				id := event.Id + 1
+				next = &id
+			}
+			return handler(event)
+		})
+
+		var handlerErr *eventHandlerError
+		if errors.As(err, &handlerErr) {
+			return handlerErr.err
+		}
+
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+
+		if err != nil && c.onError != nil {
+			c.onError(err)
+		}
+
+		if err := sleepContext(ctx, c.reconnectDelay); err != nil {
+			return err
+		}
+	}
+}
+
+// Subscribe is the channel based version of Listen.
+// The events channel is closed when ctx is done, the error channel then receives the reason
+func (c *EventStreamClient) Subscribe(ctx context.Context, stream EventStream, startFrom *uint64) (<-chan Event, <-chan error) {
+	events := make(chan Event)
+	errs := make(chan error, 1)
+
+	go func() {
+		defer close(errs)
+		defer close(events)
+
+		errs <- c.Listen(ctx, stream, startFrom, func(event Event) error {
+			select {
+			case events <- event:
+				return nil
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		})
+	}()
+
+	return events, errs
+}
+
+type eventHandlerError struct {
+	err error
+}
+
+func (e *eventHandlerError) Error() string {
+	return e.err.Error()
+}
+
+// listen reads a single connection until it ends
+func (c *EventStreamClient) listen(ctx context.Context, stream EventStream, startFrom *uint64, handler func(Event) error) error {
+	url := c.url + string(stream)
+	if startFrom != nil {
+		url = fmt.Sprintf("%s?start_from=%d", url, *startFrom)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Accept", "text/event-stream")
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return &HttpError{Err: err}
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		b, _ := ioutil.ReadAll(io.LimitReader(resp.Body, 1024))
+		return &HttpError{StatusCode: resp.StatusCode, Body: string(b)}
+	}
+
+	reader := bufio.NewReader(resp.Body)
+	var data bytes.Buffer
+	var id *uint64
+
+	for {
+		line, err := reader.ReadString('\n')
+		if err != nil {
+			if err == io.EOF {
+				return errors.New("event stream closed by node")
+			}
+			return fmt.Errorf("failed to read event stream: %w", err)
+		}
+
+		line = strings.TrimRight(line, "\r\n")
+
+		if line == "" {
+			if data.Len() == 0 {
+				continue
+			}
+
+			event, err := ParseEvent(data.Bytes())
+			data.Reset()
+			if err != nil {
+				if c.onError != nil {
+					c.onError(err)
+				}
+				id = nil
+				continue
+			}
+
+			if id != nil {
+				event.Id = *id
+				id = nil
+			}
+
+			if err := handler(event); err != nil {
+				return &eventHandlerError{err: err}
+			}
+			continue
+		}
+
+		if strings.HasPrefix(line, ":") {
+			continue
+		}
+
+		field, value := line, ""
+		if i := strings.Index(line, ":"); i >= 0 {
+			field, value = line[:i], strings.TrimPrefix(line[i+1:], " ")
+		}
+
+		switch field {
+		case "data":
+			if data.Len() > 0 {
+				data.WriteByte('\n')
+			}
+			data.WriteString(value)
+		case "id":
+			parsed, err := strconv.ParseUint(value, 10, 64)
+			if err == nil {
+				id = &parsed
+			}
+		}
+	}
+}
@@ -0,0 +1,128 @@
+package sdk
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const testApiVersionEvent = `data:{"ApiVersion":"1.4.3"}`
+
+const testBlockAddedEvent = `data:{"BlockAdded":{"block_hash":"5ab0a6e8d3e3a3ab6ad38e3ddd0ac2b5d4b10b2a25c4b5a1a1e28c0b6f2c5a01","block":{"hash":"5ab0a6e8d3e3a3ab6ad38e3ddd0ac2b5d4b10b2a25c4b5a1a1e28c0b6f2c5a01","header":{"era_id":10,"height":1034},"body":{"proposer":"01d995c93ac47e763433b5ec973cac464c7343d76d6bd47c936cf8ce5d83032061","deploy_hashes":[],"transfer_hashes":[]},"proofs":[]}}}
+id:1`
+
+const testDeployProcessedEvent = `data:{"DeployProcessed":{"deploy_hash":"48b33972cdc075d82363279640490b64bcac26cd540c8cf16da688d400c86b66","account":"01d995c93ac47e763433b5ec973cac464c7343d76d6bd47c936cf8ce5d83032061","timestamp":"2021-09-13T17:51:59.181Z","ttl":"30m","dependencies":[],"block_hash":"5ab0a6e8d3e3a3ab6ad38e3ddd0ac2b5d4b10b2a25c4b5a1a1e28c0b6f2c5a01","execution_result":{"Success":{"transfers":[],"cost":"100000000"}}}}
+id:2`
+
+const testFinalitySignatureEvent = `id:3
+data:{"FinalitySignature":{"block_hash":"5ab0a6e8d3e3a3ab6ad38e3ddd0ac2b5d4b10b2a25c4b5a1a1e28c0b6f2c5a01","era_id":10,"signature":"01aa","public_key":"01d995c93ac47e763433b5ec973cac464c7343d76d6bd47c936cf8ce5d83032061"}}`
+
+const testDeployExpiredEvent = `: keep-alive
+
+data:{"DeployExpired":{"deploy_hash":"48b33972cdc075d82363279640490b64bcac26cd540c8cf16da688d400c86b66"}}
+id:4`
+
+func TestParseEvent(t *testing.T) {
+	event, err := ParseEvent([]byte(`{"Fault":{"era_id":4,"public_key":"01d995c93ac47e763433b5ec973cac464c7343d76d6bd47c936cf8ce5d83032061","timestamp":"2021-09-13T17:51:59.181Z"}}`))
+	if assert.NoError(t, err) {
+		assert.Equal(t, EventTypeFault, event.Type)
+		assert.Equal(t, uint64(4), event.Fault.EraId)
+	}
+
+	event, err = ParseEvent([]byte(`{"Step":{"era_id":5,"execution_effect":{"operations":[],"transforms":[]}}}`))
+	if assert.NoError(t, err) {
+		assert.Equal(t, EventTypeStep, event.Type)
+		assert.Equal(t, uint64(5), event.Step.EraId)
+		assert.NotEmpty(t, event.Step.ExecutionEffect)
+	}
+
+	event, err = ParseEvent([]byte(`"Shutdown"`))
+	if assert.NoError(t, err) {
+		assert.Equal(t, EventTypeShutdown, event.Type)
+	}
+
+	_, err = ParseEvent([]byte(`{"Unknown":{}}`))
+	assert.Error(t, err)
+}
+
+func TestEventStreamClient_Reconnect(t *testing.T) {
+	var connections int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, string(EventStreamMain), r.URL.Path)
+		w.Header().Set("Content-Type", "text/event-stream")
+
+		switch atomic.AddInt32(&connections, 1) {
+		case 1:
+			assert.Equal(t, "", r.URL.Query().Get("start_from"))
+			fmt.Fprintf(w, "%s\n\n%s\n\n%s\n\n", testApiVersionEvent, testBlockAddedEvent, testDeployProcessedEvent)
+		default:
+			assert.Equal(t, "3", r.URL.Query().Get("start_from"))
+			fmt.Fprintf(w, "%s\n\n%s\n\n%s\n\n", testApiVersionEvent, testFinalitySignatureEvent, testDeployExpiredEvent)
+			w.(http.Flusher).Flush()
+			<-r.Context().Done()
+		}
+	}))
+	defer server.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	streamClient := NewEventStreamClient(server.URL, WithReconnectDelay(10*time.Millisecond))
+	events, errs := streamClient.Subscribe(ctx, EventStreamMain, nil)
+
+	received := make([]Event, 0)
+	for event := range events {
+		received = append(received, event)
+		if len(received) == 6 {
+			cancel()
+		}
+	}
+	assert.Equal(t, context.Canceled, <-errs)
+
+	if !assert.Len(t, received, 6) {
+		return
+	}
+
+	assert.Equal(t, EventTypeApiVersion, received[0].Type)
+	assert.Equal(t, "1.4.3", *received[0].ApiVersion)
+
+	assert.Equal(t, EventTypeBlockAdded, received[1].Type)
+	assert.Equal(t, uint64(1), received[1].Id)
+	assert.Equal(t, uint64(1034), received[1].BlockAdded.Block.Header.Height)
+
+	assert.Equal(t, EventTypeDeployProcessed, received[2].Type)
+	assert.Equal(t, uint64(2), received[2].Id)
+	assert.Equal(t, "100000000", received[2].DeployProcessed.ExecutionResult.Success.Cost)
+
+	assert.Equal(t, EventTypeApiVersion, received[3].Type)
+
+	assert.Equal(t, EventTypeFinalitySignature, received[4].Type)
+	assert.Equal(t, uint64(3), received[4].Id)
+	assert.Equal(t, uint64(10), received[4].FinalitySignature.EraId)
+
+	assert.Equal(t, EventTypeDeployExpired, received[5].Type)
+	assert.Equal(t, uint64(4), received[5].Id)
+	assert.Equal(t, "48b33972cdc075d82363279640490b64bcac26cd540c8cf16da688d400c86b66", received[5].DeployExpired.DeployHash)
+}
+
+func TestEventStreamClient_HandlerError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, "%s\n\n%s\n\n", testApiVersionEvent, testBlockAddedEvent)
+	}))
+	defer server.Close()
+
+	stop := fmt.Errorf("stop")
+	err := NewEventStreamClient(server.URL).Listen(context.Background(), EventStreamMain, nil, func(event Event) error {
+		if event.Type == EventTypeBlockAdded {
+			return stop
+		}
+		return nil
+	})
+	assert.Equal(t, stop, err)
+}
@@ -0,0 +1,206 @@
+package sdk
+
+import (
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/casper-ecosystem/casper-golang-sdk/keypair"
+)
+
+var (
+	ErrUnknownFinalitySigner    = errors.New("finality signature by a key which isn't a validator of the era")
+	ErrDuplicateFinalitySigner  = errors.New("duplicate finality signature")
+	ErrInvalidFinalitySignature = errors.New("invalid finality signature")
+	ErrInsufficientFinality     = errors.New("signed weight doesn't exceed the finality threshold")
+	ErrUnexpectedEra            = errors.New("block is not in the era of the validators")
+	ErrMissingValidatorWeights  = errors.New("missing validator weights")
+)
+
+// DefaultFinalityThreshold is the share of the total validator weight the signatures of a block have to exceed,
+// the fault tolerance fraction used by the nodes
+var DefaultFinalityThreshold = big.NewRat(1, 3)
+
+// ValidatorWeights maps the hex encoded public keys of the validators of an era to their weights
+type ValidatorWeights map[string]*big.Int
+
+// Total returns the summed weight of all validators
+func (w ValidatorWeights) Total() *big.Int {
+	total := new(big.Int)
+	for _, weight := range w {
+		total.Add(total, weight)
+	}
+	return total
+}
+
+func (w ValidatorWeights) weight(publicKey string) (*big.Int, bool) {
+	for validator, weight := range w {
+		if strings.EqualFold(validator, publicKey) {
+			return weight, true
+		}
+	}
+	return nil, false
+}
+
+// EraValidatorWeights returns the validator weights of the era, false if the auction state has no validators for it
+func (s AuctionState) EraValidatorWeights(eraId uint64) (ValidatorWeights, bool) {
+	for _, era := range s.EraValidators {
+		if uint64(era.EraId) != eraId {
+			continue
+		}
+
+		weights := make(ValidatorWeights, len(era.ValidatorWeights))
+		for _, validator := range era.ValidatorWeights {
+			weight, ok := new(big.Int).SetString(validator.Weight, 10)
+			if !ok {
+				return nil, false
+			}
+			weights[validator.PublicKey] = weight
+		}
+		return weights, true
+	}
+	return nil, false
+}
+
+// ValidatorWeights returns the validator weights of the era following the one ended by the switch block
+func (e EraEnd) ValidatorWeights() ValidatorWeights {
+	weights := make(ValidatorWeights, len(e.NextEraValidatorWeights))
+	for _, validator := range e.NextEraValidatorWeights {
+		weights[validator.Validator] = new(big.Int).Set(&validator.Weight.Int)
+	}
+	return weights
+}
+
+// FinalitySignatureMessage returns the message signed by the validators finalizing a block, the block hash followed by the era id
+func FinalitySignatureMessage(blockHash string, eraId uint64) ([]byte, error) {
+	hash, err := hex.DecodeString(blockHash)
+	if err != nil || len(hash) != 32 {
+		return nil, fmt.Errorf("invalid block hash %q", blockHash)
+	}
+
+	message := make([]byte, 40)
+	copy(message, hash)
+	binary.LittleEndian.PutUint64(message[32:], eraId)
+	return message, nil
+}
+
+// VerifyFinality verifies the block and the signatures of its proofs, each signer has to be one of the validators.
+// It returns the signed weight, which has to exceed the threshold share of the total weight, nil uses DefaultFinalityThreshold
+func (b BlockResponse) VerifyFinality(validators ValidatorWeights, threshold *big.Rat) (*big.Int, error) {
+	if len(validators) == 0 {
+		return nil, ErrMissingValidatorWeights
+	}
+	if threshold == nil {
+		threshold = DefaultFinalityThreshold
+	}
+
+	if err := b.VerifyBlock(); err != nil {
+		return nil, err
+	}
+
+	message, err := FinalitySignatureMessage(b.Hash, b.Header.EraID)
+	if err != nil {
+		return nil, err
+	}
+
+	signed := new(big.Int)
+	signers := make(map[string]bool, len(b.Proofs))
+
+	for i, proof := range b.Proofs {
+		signer := strings.ToLower(proof.PublicKey)
+
+		weight, ok := validators.weight(signer)
+		if !ok {
+			return nil, fmt.Errorf("%w: proof %d by %s", ErrUnknownFinalitySigner, i, proof.PublicKey)
+		}
+		if signers[signer] {
+			return nil, fmt.Errorf("%w: %s", ErrDuplicateFinalitySigner, proof.PublicKey)
+		}
+		signers[signer] = true
+
+		if !proof.verify(message) {
+			return nil, fmt.Errorf("%w: proof %d by %s", ErrInvalidFinalitySignature, i, proof.PublicKey)
+		}
+
+		signed.Add(signed, weight)
+	}
+
+	// signed / total > numerator / denominator
+	total := validators.Total()
+	if new(big.Int).Mul(signed, threshold.Denom()).Cmp(new(big.Int).Mul(total, threshold.Num())) <= 0 {
+		return signed, fmt.Errorf("%w: signed %s of %s", ErrInsufficientFinality, signed, total)
+	}
+
+	return signed, nil
+}
+
+func (p Proof) verify(message []byte) bool {
+	publicKey, err := hex.DecodeString(p.PublicKey)
+	if err != nil || len(publicKey) == 0 {
+		return false
+	}
+
+	signature, err := hex.DecodeString(p.Signature)
+	if err != nil || len(signature) == 0 {
+		return false
+	}
+
+	return keypair.PublicKey{
+		Tag:        keypair.KeyTag(publicKey[0]),
+		PubKeyData: publicKey[1:],
+	}.Verify(message, keypair.Signature{
+		Tag:           keypair.KeyTag(signature[0]),
+		SignatureData: signature[1:],
+	})
+}
+
+// FinalityVerifier verifies blocks with the validators of a trusted era without trusting the node returning them.
+// Verified switch blocks move it to the next era, so it can follow the chain from era to era
+type FinalityVerifier struct {
+	EraID      uint64
+	Validators ValidatorWeights
+	// Threshold is the share of the total weight the signatures have to exceed, nil uses DefaultFinalityThreshold
+	Threshold *big.Rat
+}
+
+// NewFinalityVerifier creates a verifier trusting the validators of the era, e.g. from AuctionState.EraValidatorWeights
+func NewFinalityVerifier(eraId uint64, validators ValidatorWeights) *FinalityVerifier {
+	return &FinalityVerifier{
+		EraID:      eraId,
+		Validators: validators,
+	}
+}
+
+// Verify checks the finality of a block of the current era, a switch block moves the verifier to the next era
+func (v *FinalityVerifier) Verify(block BlockResponse) error {
+	if block.Header.EraID != v.EraID {
+		return fmt.Errorf("%w: expected era %d, got %d", ErrUnexpectedEra, v.EraID, block.Header.EraID)
+	}
+
+	if _, err := block.VerifyFinality(v.Validators, v.Threshold); err != nil {
+		return err
+	}
+
+	if block.Header.EraEnd != nil {
+		v.EraID++
+		v.Validators = block.Header.EraEnd.ValidatorWeights()
+	}
+
+	return nil
+}
+
+// VerifySwitchBlocks verifies consecutive switch blocks starting with the one of the verifier's era
+func (v *FinalityVerifier) VerifySwitchBlocks(blocks []BlockResponse) error {
+	for _, block := range blocks {
+		if block.Header.EraEnd == nil {
+			return fmt.Errorf("block %s is not a switch block", block.Hash)
+		}
+		if err := v.Verify(block); err != nil {
+			return err
+		}
+	}
+	return nil
+}
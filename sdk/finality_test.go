@@ -0,0 +1,171 @@
+package sdk
+
+import (
+	"bytes"
+	"encoding/hex"
+	"errors"
+	"math/big"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/casper-ecosystem/casper-golang-sdk/keypair"
+	"github.com/casper-ecosystem/casper-golang-sdk/keypair/ed25519"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/crypto/blake2b"
+)
+
+func testValidatorKeys() []keypair.KeyPair {
+	keys := make([]keypair.KeyPair, 3)
+	for i := range keys {
+		keys[i] = ed25519.Ed25519FromSeed(bytes.Repeat([]byte{byte(i + 1)}, 32))
+	}
+	return keys
+}
+
+func publicKeyHex(key keypair.KeyPair) string {
+	publicKey, _ := key.PublicKey().ToBytes()
+	return hex.EncodeToString(publicKey)
+}
+
+// testFinalizedBlock creates a block of the era with valid hashes, signed by the signers
+func testFinalizedBlock(t *testing.T, eraId, height uint64, eraEnd *EraEnd, signers ...keypair.KeyPair) BlockResponse {
+	block := BlockResponse{
+		Header: BlockHeader{
+			ParentHash:      strings.Repeat("0a", 32),
+			StateRootHash:   strings.Repeat("0b", 32),
+			AccumulatedSeed: strings.Repeat("0c", 32),
+			EraEnd:          eraEnd,
+			Timestamp:       time.Date(2021, 11, 10, 10, 0, 0, 0, time.UTC),
+			EraID:           eraId,
+			Height:          height,
+			ProtocolVersion: "1.4.3",
+		},
+		Body: BlockBody{
+			Proposer:       publicKeyHex(signers[0]),
+			DeployHashes:   []string{},
+			TransferHashes: []string{},
+		},
+	}
+
+	body, err := block.Body.ToBytes()
+	assert.NoError(t, err)
+	bodyHash := blake2b.Sum256(body)
+	block.Header.BodyHash = hex.EncodeToString(bodyHash[:])
+
+	header, err := block.Header.ToBytes()
+	assert.NoError(t, err)
+	blockHash := blake2b.Sum256(header)
+	block.Hash = hex.EncodeToString(blockHash[:])
+
+	message, err := FinalitySignatureMessage(block.Hash, eraId)
+	assert.NoError(t, err)
+
+	for _, signer := range signers {
+		var signature bytes.Buffer
+		_, err := signer.Sign(message).Marshal(&signature)
+		assert.NoError(t, err)
+
+		block.Proofs = append(block.Proofs, Proof{
+			PublicKey: publicKeyHex(signer),
+			Signature: hex.EncodeToString(signature.Bytes()),
+		})
+	}
+
+	return block
+}
+
+func TestBlockResponse_VerifyFinality(t *testing.T) {
+	keys := testValidatorKeys()
+	validators := ValidatorWeights{
+		publicKeyHex(keys[0]): big.NewInt(50),
+		publicKeyHex(keys[1]): big.NewInt(30),
+		publicKeyHex(keys[2]): big.NewInt(20),
+	}
+
+	block := testFinalizedBlock(t, 10, 100, nil, keys[1], keys[2])
+	signed, err := block.VerifyFinality(validators, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, "50", signed.String())
+
+	// 50 of 100 doesn't exceed two thirds
+	_, err = block.VerifyFinality(validators, big.NewRat(2, 3))
+	assert.True(t, errors.Is(err, ErrInsufficientFinality))
+
+	// exactly a third isn't enough
+	_, err = testFinalizedBlock(t, 10, 100, nil, keys[1]).VerifyFinality(ValidatorWeights{
+		publicKeyHex(keys[0]): big.NewInt(40),
+		publicKeyHex(keys[1]): big.NewInt(30),
+		publicKeyHex(keys[2]): big.NewInt(20),
+	}, nil)
+	assert.True(t, errors.Is(err, ErrInsufficientFinality))
+
+	tampered := block
+	tampered.Proofs = append([]Proof{}, block.Proofs...)
+	tampered.Proofs[0].Signature = block.Proofs[1].Signature
+	_, err = tampered.VerifyFinality(validators, nil)
+	assert.True(t, errors.Is(err, ErrInvalidFinalitySignature))
+
+	tampered.Proofs = append(block.Proofs, block.Proofs[0])
+	_, err = tampered.VerifyFinality(validators, nil)
+	assert.True(t, errors.Is(err, ErrDuplicateFinalitySigner))
+
+	_, err = block.VerifyFinality(ValidatorWeights{publicKeyHex(keys[0]): big.NewInt(1)}, nil)
+	assert.True(t, errors.Is(err, ErrUnknownFinalitySigner))
+
+	// the era id is part of the block hash, so a block can't be moved to another era
+	tampered = block
+	tampered.Header.EraID = 11
+	_, err = tampered.VerifyFinality(validators, nil)
+	assert.True(t, errors.Is(err, ErrInvalidBlockHash))
+
+	_, err = block.VerifyFinality(nil, nil)
+	assert.True(t, errors.Is(err, ErrMissingValidatorWeights))
+}
+
+func TestFinalityVerifier_VerifySwitchBlocks(t *testing.T) {
+	keys := testValidatorKeys()
+
+	state := AuctionState{EraValidators: []EraValidators{{
+		EraId: 10,
+		ValidatorWeights: []ValidatorWeight{
+			{PublicKey: publicKeyHex(keys[0]), Weight: "100"},
+			{PublicKey: publicKeyHex(keys[1]), Weight: "100"},
+		},
+	}}}
+	trusted, ok := state.EraValidatorWeights(10)
+	if !assert.True(t, ok) {
+		return
+	}
+
+	// era 11 is validated by keys 1 and 2 only, era 12 by key 2 only
+	switchBlocks := []BlockResponse{
+		testFinalizedBlock(t, 10, 100, &EraEnd{NextEraValidatorWeights: []NextEraValidatorWeight{
+			{Validator: publicKeyHex(keys[1]), Weight: NewBigInt(big.NewInt(100))},
+			{Validator: publicKeyHex(keys[2]), Weight: NewBigInt(big.NewInt(300))},
+		}}, keys[0], keys[1]),
+		testFinalizedBlock(t, 11, 200, &EraEnd{NextEraValidatorWeights: []NextEraValidatorWeight{
+			{Validator: publicKeyHex(keys[2]), Weight: NewBigInt(big.NewInt(1))},
+		}}, keys[2]),
+	}
+
+	verifier := NewFinalityVerifier(10, trusted)
+	assert.NoError(t, verifier.VerifySwitchBlocks(switchBlocks))
+	assert.Equal(t, uint64(12), verifier.EraID)
+	assert.Len(t, verifier.Validators, 1)
+
+	assert.NoError(t, verifier.Verify(testFinalizedBlock(t, 12, 250, nil, keys[2])))
+	assert.Equal(t, uint64(12), verifier.EraID)
+
+	// key 0 was no validator of era 12
+	err := verifier.Verify(testFinalizedBlock(t, 12, 251, nil, keys[0]))
+	assert.True(t, errors.Is(err, ErrUnknownFinalitySigner))
+
+	err = verifier.Verify(testFinalizedBlock(t, 13, 300, nil, keys[2]))
+	assert.True(t, errors.Is(err, ErrUnexpectedEra))
+
+	// key 0 is no validator of era 11
+	verifier = NewFinalityVerifier(10, trusted)
+	err = verifier.VerifySwitchBlocks([]BlockResponse{switchBlocks[0], testFinalizedBlock(t, 11, 200, &EraEnd{}, keys[0])})
+	assert.True(t, errors.Is(err, ErrUnknownFinalitySigner))
+}
@@ -0,0 +1,196 @@
+package sdk
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"sync"
+
+	"github.com/pkg/errors"
+)
+
+// maxConcurrentFallbackCalls limits the parallel single calls made when a node rejects batch requests
+const maxConcurrentFallbackCalls = 8
+
+// BatchCall is a single json rpc call of a batch request
+type BatchCall struct {
+	Method string
+	Params interface{}
+}
+
+// BatchResult holds the outcome of one call of a batch request.
+// Err is either an *RpcError returned by the node for this call or a local failure
+type BatchResult struct {
+	Result json.RawMessage
+	Err    error
+}
+
+// Decode unmarshals the result of the call into dest
+func (r BatchResult) Decode(dest interface{}) error {
+	if r.Err != nil {
+		return r.Err
+	}
+
+	err := json.Unmarshal(r.Result, dest)
+	if err != nil {
+		return errors.Wrap(err, "failed to get result")
+	}
+
+	return nil
+}
+
+// Block decodes the result of a chain_get_block call
+func (r BatchResult) Block() (BlockResponse, error) {
+	var result blockResult
+	if err := r.Decode(&result); err != nil {
+		return BlockResponse{}, err
+	}
+
+	return result.Block, nil
+}
+
+// Transfers decodes the result of a chain_get_block_transfers call
+func (r BatchResult) Transfers() ([]TransferResponse, error) {
+	var result transferResult
+	if err := r.Decode(&result); err != nil {
+		return nil, err
+	}
+
+	return result.Transfers, nil
+}
+
+// BlockByHeightCall builds a chain_get_block call for a batch request
+func BlockByHeightCall(height uint64) BatchCall {
+	return BatchCall{
+		Method: "chain_get_block",
+		Params: blockParams{BlockByHeight(height)},
+	}
+}
+
+// BlockTransfersByHeightCall builds a chain_get_block_transfers call for a batch request
+func BlockTransfersByHeightCall(height uint64) BatchCall {
+	return BatchCall{
+		Method: "chain_get_block_transfers",
+		Params: blockParams{BlockByHeight(height)},
+	}
+}
+
+// Batch sends all calls in a single json rpc batch request, see BatchContext
+func (c *RpcClient) Batch(calls []BatchCall) ([]BatchResult, error) {
+	return c.BatchContext(context.Background(), calls)
+}
+
+// BatchContext sends all calls in a single json rpc batch request and returns the results in the order of calls.
+// If the node rejects batch requests, the calls are made individually and concurrently instead.
+// The returned error is only set when the request as a whole failed, errors of single calls are reported in BatchResult.Err
+func (c *RpcClient) BatchContext(ctx context.Context, calls []BatchCall) ([]BatchResult, error) {
+	if len(calls) == 0 {
+		return []BatchResult{}, nil
+	}
+
+	requests := make([]RpcRequest, len(calls))
+	indexes := make(map[string]int, len(calls))
+	attempts := c.attempts("")
+
+	for i, call := range calls {
+		requests[i] = RpcRequest{
+			Version: "2.0",
+			Id:      c.nextId(),
+			Method:  call.Method,
+			Params:  call.Params,
+		}
+		indexes[requests[i].Id] = i
+
+		if callAttempts := c.attempts(call.Method); callAttempts < attempts {
+			attempts = callAttempts
+		}
+	}
+
+	body, err := json.Marshal(requests)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to marshal json")
+	}
+
+	b, err := c.send(ctx, attempts, body)
+	if err != nil {
+		if isBatchRejected(err) {
+			return c.batchFallback(ctx, calls), nil
+		}
+		return nil, err
+	}
+
+	b = bytes.TrimSpace(b)
+	if len(b) > 0 && b[0] == '{' {
+		// a single response object instead of an array means the node did not accept the batch
+		return c.batchFallback(ctx, calls), nil
+	}
+
+	var responses []RpcResponse
+	err = json.Unmarshal(b, &responses)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to parse response body")
+	}
+
+	results := make([]BatchResult, len(calls))
+	received := make([]bool, len(calls))
+
+	for _, response := range responses {
+		i, ok := indexes[response.Id]
+		if !ok {
+			continue
+		}
+
+		received[i] = true
+		if response.Error != nil {
+			results[i].Err = response.Error
+		} else {
+			results[i].Result = response.Result
+		}
+	}
+
+	for i := range results {
+		if !received[i] {
+			results[i].Err = errors.Errorf("no response for call %s with id %s", calls[i].Method, requests[i].Id)
+		}
+	}
+
+	return results, nil
+}
+
+func (c *RpcClient) batchFallback(ctx context.Context, calls []BatchCall) []BatchResult {
+	results := make([]BatchResult, len(calls))
+	semaphore := make(chan struct{}, maxConcurrentFallbackCalls)
+
+	var wg sync.WaitGroup
+	for i, call := range calls {
+		wg.Add(1)
+		go func(i int, call BatchCall) {
+			defer wg.Done()
+			semaphore <- struct{}{}
+			defer func() { <-semaphore }()
+
+			resp, err := c.rpcCall(ctx, call.Method, call.Params)
+			if err != nil {
+				results[i].Err = err
+				return
+			}
+			results[i].Result = resp.Result
+		}(i, call)
+	}
+	wg.Wait()
+
+	return results
+}
+
+// isBatchRejected reports whether the node refused the batch request itself, rather than failing to answer it
+func isBatchRejected(err error) bool {
+	var httpErr *HttpError
+	if !errors.As(err, &httpErr) {
+		return false
+	}
+
+	return httpErr.StatusCode >= http.StatusBadRequest &&
+		httpErr.StatusCode < http.StatusInternalServerError &&
+		httpErr.StatusCode != http.StatusTooManyRequests
+}
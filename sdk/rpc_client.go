@@ -2,30 +2,97 @@ package sdk
 
 import (
 	"bytes"
+	"context"
+	"encoding/hex"
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
 	"math/big"
 	"net/http"
+	"strconv"
+	"sync/atomic"
 	"time"
 
 	"github.com/casper-ecosystem/casper-golang-sdk/keypair"
+	"github.com/casper-ecosystem/casper-golang-sdk/types"
 
 	"github.com/pkg/errors"
 )
 
+const methodPutDeploy = "account_put_deploy"
+
 type RpcClient struct {
-	endpoint string
+	// lastId is accessed atomically and kept first for 64-bit alignment
+	lastId      uint64
+	endpoints   []string
+	current     uint32
+	httpClient  *http.Client
+	headers     http.Header
+	timeout     time.Duration
+	retryPolicy *RetryPolicy
+}
+
+// RpcClientOption configures optional RpcClient settings
+type RpcClientOption func(*RpcClient)
+
+// WithHTTPClient sets the http.Client used for requests, e.g. to configure TLS, proxies or transports
+func WithHTTPClient(httpClient *http.Client) RpcClientOption {
+	return func(c *RpcClient) {
+		c.httpClient = httpClient
+	}
+}
+
+// WithHeader adds a header which is sent with every request
+func WithHeader(key, value string) RpcClientOption {
+	return func(c *RpcClient) {
+		c.headers.Add(key, value)
+	}
 }
 
-func NewRpcClient(endpoint string) *RpcClient {
-	return &RpcClient{
-		endpoint: endpoint,
+// WithTimeout sets the deadline applied to every single call, zero means no timeout
+func WithTimeout(timeout time.Duration) RpcClientOption {
+	return func(c *RpcClient) {
+		c.timeout = timeout
 	}
 }
 
+// ErrNoEndpoints is returned by NewRpcClientWithEndpoints for an empty endpoint list
+var ErrNoEndpoints = errors.New("rpc client needs at least one endpoint")
+
+func NewRpcClient(endpoint string, opts ...RpcClientOption) *RpcClient {
+	return newRpcClient([]string{endpoint}, opts...)
+}
+
+// NewRpcClientWithEndpoints creates a client for a pool of nodes, it fails with ErrNoEndpoints if no endpoint is given.
+// Calls go to one endpoint at a time, combined with WithRetryPolicy the client fails over to the next endpoint when a call fails
+func NewRpcClientWithEndpoints(endpoints []string, opts ...RpcClientOption) (*RpcClient, error) {
+	if len(endpoints) == 0 {
+		return nil, ErrNoEndpoints
+	}
+
+	return newRpcClient(append([]string{}, endpoints...), opts...), nil
+}
+
+func newRpcClient(endpoints []string, opts ...RpcClientOption) *RpcClient {
+	c := &RpcClient{
+		endpoints:  endpoints,
+		httpClient: http.DefaultClient,
+		headers:    make(http.Header),
+	}
+
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	return c
+}
+
 func (c *RpcClient) GetDeploy(hash string) (DeployResult, error) {
-	resp, err := c.rpcCall("info_get_deploy", map[string]string{
+	return c.GetDeployContext(context.Background(), hash)
+}
+
+func (c *RpcClient) GetDeployContext(ctx context.Context, hash string) (DeployResult, error) {
+	resp, err := c.rpcCall(ctx, "info_get_deploy", map[string]string{
 		"deploy_hash": hash,
 	})
 	if err != nil {
@@ -43,6 +110,10 @@ func (c *RpcClient) GetDeploy(hash string) (DeployResult, error) {
 }
 
 func (c *RpcClient) GetStateItem(stateRootHash, key string, path []string) (StoredValue, error) {
+	return c.GetStateItemContext(context.Background(), stateRootHash, key, path)
+}
+
+func (c *RpcClient) GetStateItemContext(ctx context.Context, stateRootHash, key string, path []string) (StoredValue, error) {
 	params := map[string]interface{}{
 		"state_root_hash": stateRootHash,
 		"key":             key,
@@ -50,7 +121,7 @@ func (c *RpcClient) GetStateItem(stateRootHash, key string, path []string) (Stor
 	if len(path) > 0 {
 		params["path"] = path
 	}
-	resp, err := c.rpcCall("state_get_item", params)
+	resp, err := c.rpcCall(ctx, "state_get_item", params)
 	if err != nil {
 		return StoredValue{}, err
 	}
@@ -64,8 +135,71 @@ func (c *RpcClient) GetStateItem(stateRootHash, key string, path []string) (Stor
 	return result.StoredValue, nil
 }
 
+func (c *RpcClient) QueryGlobalState(identifier GlobalStateIdentifier, key string, path []string) (GlobalStateResult, error) {
+	return c.QueryGlobalStateContext(context.Background(), identifier, key, path)
+}
+
+// QueryGlobalStateContext queries the stored value under key and path in the global state of a block or state root hash
+func (c *RpcClient) QueryGlobalStateContext(ctx context.Context, identifier GlobalStateIdentifier, key string, path []string) (GlobalStateResult, error) {
+	if err := identifier.validate(); err != nil {
+		return GlobalStateResult{}, err
+	}
+	if path == nil {
+		path = []string{}
+	}
+
+	resp, err := c.rpcCall(ctx, "query_global_state", map[string]interface{}{
+		"state_identifier": identifier,
+		"key":              key,
+		"path":             path,
+	})
+	if err != nil {
+		return GlobalStateResult{}, err
+	}
+
+	var result GlobalStateResult
+	err = json.Unmarshal(resp.Result, &result)
+	if err != nil {
+		return GlobalStateResult{}, fmt.Errorf("failed to get result: %w", err)
+	}
+
+	return result, nil
+}
+
+func (c *RpcClient) GetDictionaryItem(stateRootHash string, identifier DictionaryIdentifier) (DictionaryItemResult, error) {
+	return c.GetDictionaryItemContext(context.Background(), stateRootHash, identifier)
+}
+
+// GetDictionaryItemContext queries a dictionary item, identified by its seed uref, by a named key of an account or
+// contract or by its dictionary key
+func (c *RpcClient) GetDictionaryItemContext(ctx context.Context, stateRootHash string, identifier DictionaryIdentifier) (DictionaryItemResult, error) {
+	if err := identifier.validate(); err != nil {
+		return DictionaryItemResult{}, err
+	}
+
+	resp, err := c.rpcCall(ctx, "state_get_dictionary_item", map[string]interface{}{
+		"state_root_hash":       stateRootHash,
+		"dictionary_identifier": identifier,
+	})
+	if err != nil {
+		return DictionaryItemResult{}, err
+	}
+
+	var result DictionaryItemResult
+	err = json.Unmarshal(resp.Result, &result)
+	if err != nil {
+		return DictionaryItemResult{}, fmt.Errorf("failed to get result: %w", err)
+	}
+
+	return result, nil
+}
+
 func (c *RpcClient) GetAccountBalance(stateRootHash, balanceUref string) (big.Int, error) {
-	resp, err := c.rpcCall("state_get_balance", map[string]string{
+	return c.GetAccountBalanceContext(context.Background(), stateRootHash, balanceUref)
+}
+
+func (c *RpcClient) GetAccountBalanceContext(ctx context.Context, stateRootHash, balanceUref string) (big.Int, error) {
+	resp, err := c.rpcCall(ctx, "state_get_balance", map[string]string{
 		"state_root_hash": stateRootHash,
 		"purse_uref":      balanceUref,
 	})
@@ -85,12 +219,16 @@ func (c *RpcClient) GetAccountBalance(stateRootHash, balanceUref string) (big.In
 }
 
 func (c *RpcClient) GetAccountMainPurseURef(accountHash string) string {
-	block, err := c.GetLatestBlock()
+	return c.GetAccountMainPurseURefContext(context.Background(), accountHash)
+}
+
+func (c *RpcClient) GetAccountMainPurseURefContext(ctx context.Context, accountHash string) string {
+	block, err := c.GetLatestBlockContext(ctx)
 	if err != nil {
 		return ""
 	}
 
-	item, err := c.GetStateItem(block.Header.StateRootHash, accountHash, []string{})
+	item, err := c.GetStateItemContext(ctx, block.Header.StateRootHash, accountHash, []string{})
 	if err != nil {
 		return ""
 	}
@@ -99,15 +237,23 @@ func (c *RpcClient) GetAccountMainPurseURef(accountHash string) string {
 }
 
 func (c *RpcClient) GetAccountBalanceByKeypair(stateRootHash string, key keypair.KeyPair) (big.Int, error) {
-	item, err := c.GetStateItem(stateRootHash, key.AccountHash(), []string{})
+	return c.GetAccountBalanceByKeypairContext(context.Background(), stateRootHash, key)
+}
+
+func (c *RpcClient) GetAccountBalanceByKeypairContext(ctx context.Context, stateRootHash string, key keypair.KeyPair) (big.Int, error) {
+	item, err := c.GetStateItemContext(ctx, stateRootHash, key.AccountHash(), []string{})
 	if err != nil {
 		return big.Int{}, err
 	}
-	return c.GetAccountBalance(stateRootHash, item.Account.MainPurse)
+	return c.GetAccountBalanceContext(ctx, stateRootHash, item.Account.MainPurse)
 }
 
 func (c *RpcClient) GetLatestBlock() (BlockResponse, error) {
-	resp, err := c.rpcCall("chain_get_block", nil)
+	return c.GetLatestBlockContext(context.Background())
+}
+
+func (c *RpcClient) GetLatestBlockContext(ctx context.Context) (BlockResponse, error) {
+	resp, err := c.rpcCall(ctx, "chain_get_block", nil)
 	if err != nil {
 		return BlockResponse{}, err
 	}
@@ -122,10 +268,12 @@ func (c *RpcClient) GetLatestBlock() (BlockResponse, error) {
 }
 
 func (c *RpcClient) GetBlockByHeight(height uint64) (BlockResponse, error) {
-	resp, err := c.rpcCall("chain_get_block",
-		blockParams{blockIdentifier{
-			Height: height,
-		}})
+	return c.GetBlockByHeightContext(context.Background(), height)
+}
+
+func (c *RpcClient) GetBlockByHeightContext(ctx context.Context, height uint64) (BlockResponse, error) {
+	resp, err := c.rpcCall(ctx, "chain_get_block",
+		blockParams{BlockByHeight(height)})
 	if err != nil {
 		return BlockResponse{}, err
 	}
@@ -140,10 +288,12 @@ func (c *RpcClient) GetBlockByHeight(height uint64) (BlockResponse, error) {
 }
 
 func (c *RpcClient) GetBlockByHash(hash string) (BlockResponse, error) {
-	resp, err := c.rpcCall("chain_get_block",
-		blockParams{blockIdentifier{
-			Hash: hash,
-		}})
+	return c.GetBlockByHashContext(context.Background(), hash)
+}
+
+func (c *RpcClient) GetBlockByHashContext(ctx context.Context, hash string) (BlockResponse, error) {
+	resp, err := c.rpcCall(ctx, "chain_get_block",
+		blockParams{BlockByHash(hash)})
 	if err != nil {
 		return BlockResponse{}, err
 	}
@@ -158,7 +308,11 @@ func (c *RpcClient) GetBlockByHash(hash string) (BlockResponse, error) {
 }
 
 func (c *RpcClient) GetLatestBlockTransfers() ([]TransferResponse, error) {
-	resp, err := c.rpcCall("chain_get_block_transfers", nil)
+	return c.GetLatestBlockTransfersContext(context.Background())
+}
+
+func (c *RpcClient) GetLatestBlockTransfersContext(ctx context.Context) ([]TransferResponse, error) {
+	resp, err := c.rpcCall(ctx, "chain_get_block_transfers", nil)
 	if err != nil {
 		return nil, err
 	}
@@ -173,10 +327,12 @@ func (c *RpcClient) GetLatestBlockTransfers() ([]TransferResponse, error) {
 }
 
 func (c *RpcClient) GetBlockTransfersByHeight(height uint64) ([]TransferResponse, error) {
-	resp, err := c.rpcCall("chain_get_block_transfers",
-		blockParams{blockIdentifier{
-			Height: height,
-		}})
+	return c.GetBlockTransfersByHeightContext(context.Background(), height)
+}
+
+func (c *RpcClient) GetBlockTransfersByHeightContext(ctx context.Context, height uint64) ([]TransferResponse, error) {
+	resp, err := c.rpcCall(ctx, "chain_get_block_transfers",
+		blockParams{BlockByHeight(height)})
 	if err != nil {
 		return nil, err
 	}
@@ -191,10 +347,12 @@ func (c *RpcClient) GetBlockTransfersByHeight(height uint64) ([]TransferResponse
 }
 
 func (c *RpcClient) GetBlockTransfersByHash(blockHash string) ([]TransferResponse, error) {
-	resp, err := c.rpcCall("chain_get_block_transfers",
-		blockParams{blockIdentifier{
-			Hash: blockHash,
-		}})
+	return c.GetBlockTransfersByHashContext(context.Background(), blockHash)
+}
+
+func (c *RpcClient) GetBlockTransfersByHashContext(ctx context.Context, blockHash string) ([]TransferResponse, error) {
+	resp, err := c.rpcCall(ctx, "chain_get_block_transfers",
+		blockParams{BlockByHash(blockHash)})
 	if err != nil {
 		return nil, err
 	}
@@ -208,23 +366,12 @@ func (c *RpcClient) GetBlockTransfersByHash(blockHash string) ([]TransferRespons
 	return result.Transfers, nil
 }
 
-func (c *RpcClient) GetValidator() (ValidatorPesponse, error) {
-	resp, err := c.rpcCall("state_get_auction_info", nil)
-	if err != nil {
-		return ValidatorPesponse{}, err
-	}
-
-	var result validatorResult
-	err = json.Unmarshal(resp.Result, &result)
-	if err != nil {
-		return ValidatorPesponse{}, fmt.Errorf("failed to get result: #{err}")
-	}
-
-	return result.Validator, nil
+func (c *RpcClient) GetStatus() (StatusResult, error) {
+	return c.GetStatusContext(context.Background())
 }
 
-func (c *RpcClient) GetStatus() (StatusResult, error) {
-	resp, err := c.rpcCall("info_get_status", nil)
+func (c *RpcClient) GetStatusContext(ctx context.Context) (StatusResult, error) {
+	resp, err := c.rpcCall(ctx, "info_get_status", nil)
 	if err != nil {
 		return StatusResult{}, err
 	}
@@ -239,7 +386,11 @@ func (c *RpcClient) GetStatus() (StatusResult, error) {
 }
 
 func (c *RpcClient) GetPeers() (PeerResult, error) {
-	resp, err := c.rpcCall("info_get_peers", nil)
+	return c.GetPeersContext(context.Background())
+}
+
+func (c *RpcClient) GetPeersContext(ctx context.Context) (PeerResult, error) {
+	resp, err := c.rpcCall(ctx, "info_get_peers", nil)
 	if err != nil {
 		return PeerResult{}, err
 	}
@@ -254,7 +405,11 @@ func (c *RpcClient) GetPeers() (PeerResult, error) {
 }
 
 func (c *RpcClient) GetStateRootHash(stateRootHash string) (StateRootHashResult, error) {
-	resp, err := c.rpcCall("chain_get_state_root_hash", map[string]string{
+	return c.GetStateRootHashContext(context.Background(), stateRootHash)
+}
+
+func (c *RpcClient) GetStateRootHashContext(ctx context.Context, stateRootHash string) (StateRootHashResult, error) {
+	resp, err := c.rpcCall(ctx, "chain_get_state_root_hash", map[string]string{
 		"state_root_hash": stateRootHash,
 	})
 	if err != nil {
@@ -271,7 +426,11 @@ func (c *RpcClient) GetStateRootHash(stateRootHash string) (StateRootHashResult,
 }
 
 func (c *RpcClient) PutDeploy(deploy Deploy) (JsonPutDeployRes, error) {
-	resp, err := c.rpcCall("account_put_deploy", map[string]interface{}{
+	return c.PutDeployContext(context.Background(), deploy)
+}
+
+func (c *RpcClient) PutDeployContext(ctx context.Context, deploy Deploy) (JsonPutDeployRes, error) {
+	resp, err := c.rpcCall(ctx, methodPutDeploy, map[string]interface{}{
 		"deploy": deploy,
 	})
 
@@ -288,9 +447,10 @@ func (c *RpcClient) PutDeploy(deploy Deploy) (JsonPutDeployRes, error) {
 	return result, nil
 }
 
-func (c *RpcClient) rpcCall(method string, params interface{}) (RpcResponse, error) {
+func (c *RpcClient) rpcCall(ctx context.Context, method string, params interface{}) (RpcResponse, error) {
 	body, err := json.Marshal(RpcRequest{
 		Version: "2.0",
+		Id:      c.nextId(),
 		Method:  method,
 		Params:  params,
 	})
@@ -299,19 +459,9 @@ func (c *RpcClient) rpcCall(method string, params interface{}) (RpcResponse, err
 		return RpcResponse{}, errors.Wrap(err, "failed to marshal json")
 	}
 
-	resp, err := http.Post(c.endpoint, "application/json", bytes.NewReader(body))
+	b, err := c.send(ctx, c.attempts(method), body)
 	if err != nil {
-		return RpcResponse{}, fmt.Errorf("failed to make request: %w", err)
-	}
-
-	defer resp.Body.Close()
-	b, err := ioutil.ReadAll(resp.Body)
-	if err != nil {
-		return RpcResponse{}, fmt.Errorf("failed to get response body: %w", err)
-	}
-
-	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
-		return RpcResponse{}, fmt.Errorf("request failed, status code - %d, response - %s", resp.StatusCode, string(b))
+		return RpcResponse{}, err
 	}
 
 	var rpcResponse RpcResponse
@@ -321,12 +471,77 @@ func (c *RpcClient) rpcCall(method string, params interface{}) (RpcResponse, err
 	}
 
 	if rpcResponse.Error != nil {
-		return rpcResponse, fmt.Errorf("rpc call failed, code - %d, message - %s", rpcResponse.Error.Code, rpcResponse.Error.Message)
+		return rpcResponse, rpcResponse.Error
 	}
 
 	return rpcResponse, nil
 }
 
+func (c *RpcClient) nextId() string {
+	return strconv.FormatUint(atomic.AddUint64(&c.lastId, 1), 10)
+}
+
+func (c *RpcClient) attempts(method string) int {
+	if c.retryPolicy == nil {
+		return 1
+	}
+	return c.retryPolicy.attempts(method)
+}
+
+// send posts the body, retrying up to attempts times and failing over to the next endpoint on retryable errors
+func (c *RpcClient) send(ctx context.Context, attempts int, body []byte) ([]byte, error) {
+	for attempt := 0; ; attempt++ {
+		index := atomic.LoadUint32(&c.current)
+		b, err := c.post(ctx, c.endpoints[index], body)
+		if err == nil || attempt+1 >= attempts || !isRetryable(ctx, err) {
+			return b, err
+		}
+
+		atomic.CompareAndSwapUint32(&c.current, index, (index+1)%uint32(len(c.endpoints)))
+
+		if err := sleepContext(ctx, c.retryPolicy.backoff(attempt)); err != nil {
+			return nil, err
+		}
+	}
+}
+
+func (c *RpcClient) post(ctx context.Context, endpoint string, body []byte) ([]byte, error) {
+	if c.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, c.timeout)
+		defer cancel()
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	for key, values := range c.headers {
+		for _, value := range values {
+			req.Header.Add(key, value)
+		}
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, &HttpError{Err: err}
+	}
+
+	defer resp.Body.Close()
+	b, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, &HttpError{StatusCode: resp.StatusCode, Err: err}
+	}
+
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		return nil, &HttpError{StatusCode: resp.StatusCode, Body: string(b)}
+	}
+
+	return b, nil
+}
+
 type RpcRequest struct {
 	Version string      `json:"jsonrpc"`
 	Id      string      `json:"id"`
@@ -341,11 +556,6 @@ type RpcResponse struct {
 	Error   *RpcError       `json:"error,omitempty"`
 }
 
-type RpcError struct {
-	Code    int    `json:"code"`
-	Message string `json:"message"`
-}
-
 type transferResult struct {
 	Transfers []TransferResponse `json:"transfers"`
 }
@@ -373,14 +583,16 @@ type BlockResponse struct {
 }
 
 type BlockHeader struct {
-	ParentHash      string    `json:"parent_hash"`
-	StateRootHash   string    `json:"state_root_hash"`
-	BodyHash        string    `json:"body_hash"`
-	RandomBit       bool      `json:"random_bit"`
-	AccumulatedSeed string    `json:"accumulated_seed"`
+	ParentHash      string `json:"parent_hash"`
+	StateRootHash   string `json:"state_root_hash"`
+	BodyHash        string `json:"body_hash"`
+	RandomBit       bool   `json:"random_bit"`
+	AccumulatedSeed string `json:"accumulated_seed"`
+	// EraEnd is only set for switch blocks, the last blocks of their eras
+	EraEnd          *EraEnd   `json:"era_end"`
 	Timestamp       time.Time `json:"timestamp"`
-	EraID           int       `json:"era_id"`
-	Height          int       `json:"height"`
+	EraID           uint64    `json:"era_id"`
+	Height          uint64    `json:"height"`
 	ProtocolVersion string    `json:"protocol_version"`
 }
 
@@ -431,8 +643,9 @@ type JsonExecutionResult struct {
 }
 
 type ExecutionResult struct {
-	Success      SuccessExecutionResult `json:"success"`
-	ErrorMessage *string                `json:"error_message,omitempty"`
+	Success      SuccessExecutionResult  `json:"success"`
+	Failure      *FailureExecutionResult `json:"failure,omitempty"`
+	ErrorMessage *string                 `json:"error_message,omitempty"`
 }
 
 type SuccessExecutionResult struct {
@@ -440,6 +653,12 @@ type SuccessExecutionResult struct {
 	Cost      string   `json:"cost"`
 }
 
+type FailureExecutionResult struct {
+	Transfers    []string `json:"transfers"`
+	Cost         string   `json:"cost"`
+	ErrorMessage string   `json:"error_message"`
+}
+
 type storedValueResult struct {
 	StoredValue StoredValue `json:"stored_value"`
 }
@@ -452,12 +671,121 @@ type StoredValue struct {
 	ContractPackage *string               `json:"ContractPackage,omitempty"`
 	Transfer        *TransferResponse     `json:"Transfer,omitempty"`
 	DeployInfo      *JsonDeployInfo       `json:"DeployInfo,omitempty"`
+	EraInfo         *EraInfo              `json:"EraInfo,omitempty"`
 }
 
 type JsonCLValue struct {
-	Bytes  string      `json:"bytes"`
-	CLType string      `json:"cl_type"`
-	Parsed interface{} `json:"parsed"`
+	Bytes  string           `json:"bytes"`
+	CLType types.CLTypeInfo `json:"cl_type"`
+	Parsed interface{}      `json:"parsed"`
+}
+
+// ToCLValue decodes Bytes into a typed CLValue of type CLType, use its As... accessors to get native values
+func (v JsonCLValue) ToCLValue() (types.CLValue, error) {
+	src, err := hex.DecodeString(v.Bytes)
+	if err != nil {
+		return types.CLValue{}, fmt.Errorf("invalid cl value bytes: %w", err)
+	}
+
+	return types.DecodeCLValue(src, v.CLType)
+}
+
+// GlobalStateIdentifier pins a query to the state after a block or to a state root hash, exactly one of the fields has to be set
+type GlobalStateIdentifier struct {
+	BlockHash     string `json:"BlockHash,omitempty"`
+	StateRootHash string `json:"StateRootHash,omitempty"`
+}
+
+func GlobalStateByBlockHash(blockHash string) GlobalStateIdentifier {
+	return GlobalStateIdentifier{BlockHash: blockHash}
+}
+
+func GlobalStateByStateRootHash(stateRootHash string) GlobalStateIdentifier {
+	return GlobalStateIdentifier{StateRootHash: stateRootHash}
+}
+
+func (g GlobalStateIdentifier) validate() error {
+	if (g.BlockHash == "") == (g.StateRootHash == "") {
+		return errors.New("global state identifier needs exactly one of BlockHash or StateRootHash")
+	}
+	return nil
+}
+
+type GlobalStateResult struct {
+	// BlockHeader is the header of the queried block, nil for queries by state root hash
+	BlockHeader *BlockHeader `json:"block_header"`
+	StoredValue StoredValue  `json:"stored_value"`
+	MerkleProof string       `json:"merkle_proof"`
+}
+
+// DictionaryIdentifier selects a dictionary item, exactly one of the fields has to be set.
+// Use DictionaryByURef, DictionaryByAccountNamedKey, DictionaryByContractNamedKey or DictionaryByKey to create it
+type DictionaryIdentifier struct {
+	URef             *DictionaryURefIdentifier     `json:"URef,omitempty"`
+	AccountNamedKey  *DictionaryNamedKeyIdentifier `json:"AccountNamedKey,omitempty"`
+	ContractNamedKey *DictionaryNamedKeyIdentifier `json:"ContractNamedKey,omitempty"`
+	// Dictionary is the formatted dictionary key, e.g. dictionary-<hex>
+	Dictionary string `json:"Dictionary,omitempty"`
+}
+
+type DictionaryURefIdentifier struct {
+	SeedURef          string `json:"seed_uref"`
+	DictionaryItemKey string `json:"dictionary_item_key"`
+}
+
+// DictionaryNamedKeyIdentifier identifies the dictionary by the name it is stored under in the named keys of Key
+type DictionaryNamedKeyIdentifier struct {
+	Key               string `json:"key"`
+	DictionaryName    string `json:"dictionary_name"`
+	DictionaryItemKey string `json:"dictionary_item_key"`
+}
+
+// DictionaryByURef identifies an item by the seed uref of the dictionary, e.g. uref-<hex>-007
+func DictionaryByURef(seedURef, dictionaryItemKey string) DictionaryIdentifier {
+	return DictionaryIdentifier{URef: &DictionaryURefIdentifier{SeedURef: seedURef, DictionaryItemKey: dictionaryItemKey}}
+}
+
+// DictionaryByAccountNamedKey identifies an item by the named key of the account, e.g. account-hash-<hex>
+func DictionaryByAccountNamedKey(accountHash, dictionaryName, dictionaryItemKey string) DictionaryIdentifier {
+	return DictionaryIdentifier{AccountNamedKey: &DictionaryNamedKeyIdentifier{
+		Key:               accountHash,
+		DictionaryName:    dictionaryName,
+		DictionaryItemKey: dictionaryItemKey,
+	}}
+}
+
+// DictionaryByContractNamedKey identifies an item by the named key of the contract, e.g. hash-<hex>
+func DictionaryByContractNamedKey(contractHash, dictionaryName, dictionaryItemKey string) DictionaryIdentifier {
+	return DictionaryIdentifier{ContractNamedKey: &DictionaryNamedKeyIdentifier{
+		Key:               contractHash,
+		DictionaryName:    dictionaryName,
+		DictionaryItemKey: dictionaryItemKey,
+	}}
+}
+
+// DictionaryByKey identifies an item by its Dictionary key, see types.NewDictionaryKey
+func DictionaryByKey(key types.Key) DictionaryIdentifier {
+	return DictionaryIdentifier{Dictionary: key.ToFormattedString()}
+}
+
+func (d DictionaryIdentifier) validate() error {
+	set := 0
+	for _, isSet := range []bool{d.URef != nil, d.AccountNamedKey != nil, d.ContractNamedKey != nil, d.Dictionary != ""} {
+		if isSet {
+			set++
+		}
+	}
+
+	if set != 1 {
+		return errors.New("dictionary identifier needs exactly one of URef, AccountNamedKey, ContractNamedKey or Dictionary")
+	}
+	return nil
+}
+
+type DictionaryItemResult struct {
+	DictionaryKey string      `json:"dictionary_key"`
+	StoredValue   StoredValue `json:"stored_value"`
+	MerkleProof   string      `json:"merkle_proof"`
 }
 
 type JsonAccount struct {
@@ -498,41 +826,36 @@ type JsonDeployInfo struct {
 }
 
 type blockParams struct {
-	BlockIdentifier blockIdentifier `json:"block_identifier"`
-}
-
-type blockIdentifier struct {
-	Hash   string `json:"Hash,omitempty"`
-	Height uint64 `json:"Height,omitempty"`
+	BlockIdentifier BlockIdentifier `json:"block_identifier"`
 }
 
-type balanceResponse struct {
-	BalanceValue string `json:"balance_value"`
+// BlockIdentifier selects a block by hash or height, the zero value selects the latest block
+type BlockIdentifier struct {
+	Hash   string
+	Height *uint64
 }
 
-type ValidatorWeight struct {
-	PublicKey string `json:"public_key"`
-	Weight    string `json:"weight"`
+func BlockByHash(hash string) BlockIdentifier {
+	return BlockIdentifier{Hash: hash}
 }
 
-type EraValidators struct {
-	EraId            int               `json:"era_id"`
-	ValidatorWeights []ValidatorWeight `json:"validator_weights"`
+func BlockByHeight(height uint64) BlockIdentifier {
+	return BlockIdentifier{Height: &height}
 }
 
-type AuctionState struct {
-	StateRootHash string          `json:"state_root_hash"`
-	BlockHeight   uint64          `json:"block_height"`
-	EraValidators []EraValidators `json:"era_validators"`
-}
-
-type ValidatorPesponse struct {
-	Version      string `json:"jsonrpc"`
-	AuctionState `json:"auction_state"`
+// MarshalJSON encodes the identifier as {"Hash":...} or {"Height":...}, the latest block as null
+func (b BlockIdentifier) MarshalJSON() ([]byte, error) {
+	switch {
+	case b.Hash != "":
+		return json.Marshal(map[string]string{"Hash": b.Hash})
+	case b.Height != nil:
+		return json.Marshal(map[string]uint64{"Height": *b.Height})
+	}
+	return []byte("null"), nil
 }
 
-type validatorResult struct {
-	Validator ValidatorPesponse `json:"validator"`
+type balanceResponse struct {
+	BalanceValue string `json:"balance_value"`
 }
 
 type StatusResult struct {
@@ -1,10 +1,21 @@
 package sdk
 
 import (
+	"context"
 	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
 	"github.com/stretchr/testify/assert"
 	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
 	"testing"
+	"time"
+
+	"github.com/casper-ecosystem/casper-golang-sdk/types"
 )
 
 var client = NewRpcClient("http://3.136.227.9:7777/rpc")
@@ -138,7 +149,7 @@ func TestRpcClient_PutDeploy(t *testing.T) {
 		TransferId: 10,
 	}, big.NewInt(3000000000), big.NewInt(10000), "casper-test", "")
 
-	assert.True(t, deploy.ValidateDeploy())
+	assert.NoError(t, deploy.ValidateDeploy())
 	deploy.SignDeploy(sourceKeyPair)
 
 	result, err := client.PutDeploy(*deploy)
@@ -150,3 +161,388 @@ func TestRpcClient_PutDeploy(t *testing.T) {
 
 	assert.Equal(t, hex.EncodeToString(deploy.Hash), result.Hash)
 }
+
+func TestRpcClient_WithHeaderAndHTTPClient(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "Bearer token", r.Header.Get("Authorization"))
+		assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+		w.Write([]byte(`{"jsonrpc":"2.0","id":"","result":{"state_root_hash":"c0eb76e0c3c7a928a0cb43e82eb4fad683d9ad626bcd3b7835a466c0587b0fff"}}`))
+	}))
+	defer server.Close()
+
+	rpcClient := NewRpcClient(server.URL, WithHTTPClient(server.Client()), WithHeader("Authorization", "Bearer token"))
+
+	result, err := rpcClient.GetStateRootHash("")
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	assert.Equal(t, "c0eb76e0c3c7a928a0cb43e82eb4fad683d9ad626bcd3b7835a466c0587b0fff", result.StateRootHash)
+}
+
+func TestRpcClient_WithTimeout(t *testing.T) {
+	done := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-r.Context().Done():
+		case <-done:
+		}
+	}))
+	defer server.Close()
+	defer close(done)
+
+	rpcClient := NewRpcClient(server.URL, WithTimeout(50*time.Millisecond))
+
+	_, err := rpcClient.GetLatestBlock()
+	assert.True(t, errors.Is(err, context.DeadlineExceeded))
+}
+
+func TestRpcClient_ContextCancel(t *testing.T) {
+	done := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-r.Context().Done():
+		case <-done:
+		}
+	}))
+	defer server.Close()
+	defer close(done)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	time.AfterFunc(50*time.Millisecond, cancel)
+
+	_, err := NewRpcClient(server.URL).GetLatestBlockContext(ctx)
+	assert.True(t, errors.Is(err, context.Canceled))
+}
+
+func TestRpcClient_RpcError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"jsonrpc":"2.0","id":"","error":{"code":-32000,"message":"deploy not known","data":"1dfdf144"}}`))
+	}))
+	defer server.Close()
+
+	_, err := NewRpcClient(server.URL).GetDeploy("1dfdf144")
+
+	var rpcErr *RpcError
+	if !assert.True(t, errors.As(err, &rpcErr)) {
+		return
+	}
+	assert.Equal(t, RpcErrorCodeNoSuchDeploy, rpcErr.Code)
+	assert.Equal(t, "deploy not known", rpcErr.Message)
+	assert.Equal(t, `"1dfdf144"`, string(rpcErr.Data))
+	assert.True(t, errors.Is(err, ErrNoSuchDeploy))
+	assert.False(t, errors.Is(err, ErrInvalidDeploy))
+}
+
+func TestRpcClient_GetStateItemNestedCLValue(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"jsonrpc":"2.0","id":"1","result":{"api_version":"1.4.3","stored_value":{"CLValue":{"cl_type":{"List":{"Option":"U64"}},"bytes":"020000000001ff00000000000000","parsed":[null,255]}},"merkle_proof":""}}`))
+	}))
+	defer server.Close()
+
+	item, err := NewRpcClient(server.URL).GetStateItem("", "hash-01", nil)
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	assert.Equal(t, "List<Option<U64>>", item.CLValue.CLType.String())
+
+	src, _ := hex.DecodeString(item.CLValue.Bytes)
+	value, err := types.DecodeCLValue(src, item.CLValue.CLType)
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	assert.Nil(t, (*value.List)[0].Option)
+	assert.Equal(t, uint64(255), *(*value.List)[1].Option.U64)
+}
+
+func TestJsonCLValue_ToCLValue(t *testing.T) {
+	var stored StoredValue
+	err := json.Unmarshal([]byte(`{"CLValue":{"cl_type":"U256","bytes":"0400ca9a3b","parsed":"1000000000"}}`), &stored)
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	value, err := stored.CLValue.ToCLValue()
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	balance, err := value.AsBigInt()
+	assert.NoError(t, err)
+	assert.Equal(t, "1000000000", balance.String())
+
+	_, err = value.AsString()
+	assert.True(t, errors.Is(err, types.ErrCLTypeMismatch))
+
+	_, err = JsonCLValue{Bytes: "zz", CLType: types.SimpleCLType(types.CLTypeU8)}.ToCLValue()
+	assert.Error(t, err)
+}
+
+func TestRpcClient_HttpError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+		w.Write([]byte("unavailable"))
+	}))
+	defer server.Close()
+
+	_, err := NewRpcClient(server.URL).GetStatus()
+
+	var httpErr *HttpError
+	if !assert.True(t, errors.As(err, &httpErr)) {
+		return
+	}
+	assert.Equal(t, http.StatusServiceUnavailable, httpErr.StatusCode)
+	assert.Equal(t, "unavailable", httpErr.Body)
+
+	server.Close()
+	_, err = NewRpcClient(server.URL).GetStatus()
+	if !assert.True(t, errors.As(err, &httpErr)) {
+		return
+	}
+	assert.Equal(t, 0, httpErr.StatusCode)
+	assert.Error(t, httpErr.Err)
+}
+
+func TestRpcClient_RetryFailover(t *testing.T) {
+	var failingCalls, healthyCalls int32
+	failing := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&failingCalls, 1)
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer failing.Close()
+
+	healthy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&healthyCalls, 1)
+		w.Write([]byte(`{"jsonrpc":"2.0","id":"","result":{"peers":[]}}`))
+	}))
+	defer healthy.Close()
+
+	policy := DefaultRetryPolicy()
+	policy.InitialBackoff = time.Millisecond
+	rpcClient, err := NewRpcClientWithEndpoints([]string{failing.URL, healthy.URL}, WithRetryPolicy(policy))
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	_, err = rpcClient.GetPeers()
+	assert.NoError(t, err)
+	_, err = rpcClient.GetPeers()
+	assert.NoError(t, err)
+
+	assert.Equal(t, int32(1), atomic.LoadInt32(&failingCalls))
+	assert.Equal(t, int32(2), atomic.LoadInt32(&healthyCalls))
+}
+
+func TestNewRpcClientWithEndpoints_Empty(t *testing.T) {
+	for _, endpoints := range [][]string{nil, {}} {
+		rpcClient, err := NewRpcClientWithEndpoints(endpoints)
+		assert.Nil(t, rpcClient)
+		assert.True(t, errors.Is(err, ErrNoEndpoints))
+	}
+}
+
+func TestRpcClient_RetryPutDeploy(t *testing.T) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	deploy := NewTransferToUniqAddress(*source, UniqAddress{
+		PublicKey:  dest,
+		TransferId: 10,
+	}, big.NewInt(3000000000), big.NewInt(10000), "casper-test", "")
+
+	policy := DefaultRetryPolicy()
+	policy.InitialBackoff = time.Millisecond
+
+	_, err := NewRpcClient(server.URL, WithRetryPolicy(policy)).PutDeploy(*deploy)
+	assert.Error(t, err)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&calls))
+
+	policy.RetryPutDeploy = true
+	_, err = NewRpcClient(server.URL, WithRetryPolicy(policy)).PutDeploy(*deploy)
+	assert.Error(t, err)
+	assert.Equal(t, int32(1+policy.MaxAttempts), atomic.LoadInt32(&calls))
+}
+
+func TestRpcClient_NoRetryOnRpcError(t *testing.T) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		w.Write([]byte(`{"jsonrpc":"2.0","id":"","error":{"code":-32001,"message":"block not known"}}`))
+	}))
+	defer server.Close()
+
+	_, err := NewRpcClient(server.URL, WithRetryPolicy(DefaultRetryPolicy())).GetBlockByHeight(1)
+	assert.True(t, errors.Is(err, ErrNoSuchBlock))
+	assert.Equal(t, int32(1), atomic.LoadInt32(&calls))
+}
+
+func TestRpcClient_Batch(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var requests []RpcRequest
+		if !assert.NoError(t, json.NewDecoder(r.Body).Decode(&requests)) {
+			return
+		}
+
+		responses := make([]RpcResponse, 0)
+		for i := len(requests) - 1; i >= 0; i-- {
+			params := requests[i].Params.(map[string]interface{})["block_identifier"].(map[string]interface{})
+			if params["Height"].(float64) == 2 {
+				responses = append(responses, RpcResponse{Version: "2.0", Id: requests[i].Id, Error: &RpcError{Code: RpcErrorCodeNoSuchBlock, Message: "block not known"}})
+				continue
+			}
+			result := fmt.Sprintf(`{"block":{"hash":"%s","header":{"height":%v}}}`, requests[i].Method, params["Height"])
+			responses = append(responses, RpcResponse{Version: "2.0", Id: requests[i].Id, Result: json.RawMessage(result)})
+		}
+		json.NewEncoder(w).Encode(responses)
+	}))
+	defer server.Close()
+
+	results, err := NewRpcClient(server.URL).Batch([]BatchCall{BlockByHeightCall(1), BlockByHeightCall(2), BlockByHeightCall(3)})
+	if !assert.NoError(t, err) || !assert.Len(t, results, 3) {
+		return
+	}
+
+	block, err := results[0].Block()
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(1), block.Header.Height)
+
+	_, err = results[1].Block()
+	assert.True(t, errors.Is(err, ErrNoSuchBlock))
+
+	block, err = results[2].Block()
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(3), block.Header.Height)
+}
+
+func TestRpcClient_BatchFallback(t *testing.T) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var request RpcRequest
+		if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		atomic.AddInt32(&calls, 1)
+		json.NewEncoder(w).Encode(RpcResponse{Version: "2.0", Id: request.Id, Result: json.RawMessage(`{"transfers":[{"deploy_hash":"` + request.Id + `"}]}`)})
+	}))
+	defer server.Close()
+
+	results, err := NewRpcClient(server.URL).Batch([]BatchCall{BlockTransfersByHeightCall(1), BlockTransfersByHeightCall(2)})
+	if !assert.NoError(t, err) || !assert.Len(t, results, 2) {
+		return
+	}
+
+	for _, result := range results {
+		transfers, err := result.Transfers()
+		assert.NoError(t, err)
+		assert.Len(t, transfers, 1)
+	}
+	assert.Equal(t, int32(2), atomic.LoadInt32(&calls))
+}
+
+func TestRpcClient_GetDictionaryItem(t *testing.T) {
+	var params []json.RawMessage
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var request struct {
+			Params struct {
+				StateRootHash        string          `json:"state_root_hash"`
+				DictionaryIdentifier json.RawMessage `json:"dictionary_identifier"`
+			} `json:"params"`
+		}
+		json.NewDecoder(r.Body).Decode(&request)
+		assert.Equal(t, "c0eb76e0c3c7a928a0cb43e82eb4fad683d9ad626bcd3b7835a466c0587b0fff", request.Params.StateRootHash)
+		params = append(params, request.Params.DictionaryIdentifier)
+
+		w.Write([]byte(`{"jsonrpc":"2.0","id":"1","result":{"api_version":"1.4.3","dictionary_key":"dictionary-e4d32816b394f6d6d8ce2d55caf830a91d40ed9fa1a20ec556635e2662260460","stored_value":{"CLValue":{"cl_type":"U256","bytes":"0400ca9a3b","parsed":"1000000000"}},"merkle_proof":"01000000"}}`))
+	}))
+	defer server.Close()
+
+	rpcClient := NewRpcClient(server.URL)
+	stateRootHash := "c0eb76e0c3c7a928a0cb43e82eb4fad683d9ad626bcd3b7835a466c0587b0fff"
+
+	seed := types.URef{AccessRight: types.AccessRightReadAddWrite}
+	for i := range seed.Address {
+		seed.Address[i] = 0x2a
+	}
+
+	identifiers := []DictionaryIdentifier{
+		DictionaryByURef(seed.ToFormattedString(), "alice"),
+		DictionaryByAccountNamedKey("account-hash-01", "balances", "alice"),
+		DictionaryByContractNamedKey("hash-02", "balances", "alice"),
+		DictionaryByKey(types.NewDictionaryKey(seed, "alice")),
+	}
+
+	for _, identifier := range identifiers {
+		item, err := rpcClient.GetDictionaryItem(stateRootHash, identifier)
+		if !assert.NoError(t, err) {
+			return
+		}
+
+		assert.Equal(t, "dictionary-e4d32816b394f6d6d8ce2d55caf830a91d40ed9fa1a20ec556635e2662260460", item.DictionaryKey)
+		value, err := item.StoredValue.CLValue.ToCLValue()
+		assert.NoError(t, err)
+		balance, err := value.AsBigInt()
+		assert.NoError(t, err)
+		assert.Equal(t, "1000000000", balance.String())
+	}
+
+	assert.JSONEq(t, `{"URef":{"seed_uref":"`+seed.ToFormattedString()+`","dictionary_item_key":"alice"}}`, string(params[0]))
+	assert.JSONEq(t, `{"AccountNamedKey":{"key":"account-hash-01","dictionary_name":"balances","dictionary_item_key":"alice"}}`, string(params[1]))
+	assert.JSONEq(t, `{"ContractNamedKey":{"key":"hash-02","dictionary_name":"balances","dictionary_item_key":"alice"}}`, string(params[2]))
+	assert.JSONEq(t, `{"Dictionary":"dictionary-e4d32816b394f6d6d8ce2d55caf830a91d40ed9fa1a20ec556635e2662260460"}`, string(params[3]))
+
+	_, err := rpcClient.GetDictionaryItem(stateRootHash, DictionaryIdentifier{})
+	assert.Error(t, err)
+	assert.Len(t, params, 4)
+}
+
+func TestRpcClient_QueryGlobalState(t *testing.T) {
+	var requests []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var request struct {
+			Method string          `json:"method"`
+			Params json.RawMessage `json:"params"`
+		}
+		json.NewDecoder(r.Body).Decode(&request)
+		assert.Equal(t, "query_global_state", request.Method)
+		requests = append(requests, string(request.Params))
+
+		if strings.Contains(string(request.Params), "BlockHash") {
+			w.Write([]byte(`{"jsonrpc":"2.0","id":"1","result":{"api_version":"1.4.3","block_header":{"parent_hash":"0a","state_root_hash":"c0eb76e0c3c7a928a0cb43e82eb4fad683d9ad626bcd3b7835a466c0587b0fff","body_hash":"0b","random_bit":true,"accumulated_seed":"0c","era_end":null,"timestamp":"2021-11-10T10:00:00.000Z","era_id":2890,"height":265834,"protocol_version":"1.4.3"},"stored_value":{"CLValue":{"cl_type":"String","bytes":"0300000061626301","parsed":"abc"}},"merkle_proof":"01000000"}}`))
+			return
+		}
+		w.Write([]byte(`{"jsonrpc":"2.0","id":"1","result":{"api_version":"1.4.3","block_header":null,"stored_value":{"CLValue":{"cl_type":"String","bytes":"03000000616263","parsed":"abc"}},"merkle_proof":"02000000"}}`))
+	}))
+	defer server.Close()
+
+	rpcClient := NewRpcClient(server.URL)
+
+	result, err := rpcClient.QueryGlobalState(GlobalStateByBlockHash("a1b2"), "hash-01", []string{"name"})
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.Equal(t, "c0eb76e0c3c7a928a0cb43e82eb4fad683d9ad626bcd3b7835a466c0587b0fff", result.BlockHeader.StateRootHash)
+	assert.Equal(t, "01000000", result.MerkleProof)
+	assert.Equal(t, "String", result.StoredValue.CLValue.CLType.String())
+
+	result, err = rpcClient.QueryGlobalState(GlobalStateByStateRootHash("c0eb"), "hash-01", nil)
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.Nil(t, result.BlockHeader)
+	assert.Equal(t, "02000000", result.MerkleProof)
+
+	assert.JSONEq(t, `{"state_identifier":{"BlockHash":"a1b2"},"key":"hash-01","path":["name"]}`, requests[0])
+	assert.JSONEq(t, `{"state_identifier":{"StateRootHash":"c0eb"},"key":"hash-01","path":[]}`, requests[1])
+
+	_, err = rpcClient.QueryGlobalState(GlobalStateIdentifier{BlockHash: "a1b2", StateRootHash: "c0eb"}, "hash-01", nil)
+	assert.Error(t, err)
+	assert.Len(t, requests, 2)
+}
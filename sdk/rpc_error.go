@@ -0,0 +1,100 @@
+package sdk
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// Error codes returned by the casper node json rpc server
+const (
+	RpcErrorCodeParseError     = -32700
+	RpcErrorCodeInvalidRequest = -32600
+	RpcErrorCodeMethodNotFound = -32601
+	RpcErrorCodeInvalidParams  = -32602
+	RpcErrorCodeInternalError  = -32603
+
+	RpcErrorCodeNoSuchDeploy              = -32000
+	RpcErrorCodeNoSuchBlock               = -32001
+	RpcErrorCodeParseQueryKey             = -32002
+	RpcErrorCodeQueryFailed               = -32003
+	RpcErrorCodeQueryFailedToExecute      = -32004
+	RpcErrorCodeParseGetBalanceURef       = -32005
+	RpcErrorCodeGetBalanceFailed          = -32006
+	RpcErrorCodeGetBalanceFailedToExecute = -32007
+	RpcErrorCodeInvalidDeploy             = -32008
+	RpcErrorCodeNoSuchAccount             = -32009
+	RpcErrorCodeFailedToGetDictionaryURef = -32010
+	RpcErrorCodeNoDictionaryName          = -32011
+	RpcErrorCodeNoSuchMainPurse           = -32012
+	RpcErrorCodeNoSuchStateRoot           = -32013
+	RpcErrorCodeFunctionIsDisabled        = -32014
+)
+
+// Sentinel errors for the well-known error codes, to be used with errors.Is
+var (
+	ErrRpcParseError     = &RpcError{Code: RpcErrorCodeParseError, Message: "parse error"}
+	ErrRpcInvalidRequest = &RpcError{Code: RpcErrorCodeInvalidRequest, Message: "invalid request"}
+	ErrRpcMethodNotFound = &RpcError{Code: RpcErrorCodeMethodNotFound, Message: "method not found"}
+	ErrRpcInvalidParams  = &RpcError{Code: RpcErrorCodeInvalidParams, Message: "invalid params"}
+	ErrRpcInternalError  = &RpcError{Code: RpcErrorCodeInternalError, Message: "internal error"}
+
+	ErrNoSuchDeploy              = &RpcError{Code: RpcErrorCodeNoSuchDeploy, Message: "no such deploy"}
+	ErrNoSuchBlock               = &RpcError{Code: RpcErrorCodeNoSuchBlock, Message: "no such block"}
+	ErrParseQueryKey             = &RpcError{Code: RpcErrorCodeParseQueryKey, Message: "failed to parse query key"}
+	ErrQueryFailed               = &RpcError{Code: RpcErrorCodeQueryFailed, Message: "query failed"}
+	ErrQueryFailedToExecute      = &RpcError{Code: RpcErrorCodeQueryFailedToExecute, Message: "query failed to execute"}
+	ErrParseGetBalanceURef       = &RpcError{Code: RpcErrorCodeParseGetBalanceURef, Message: "failed to parse balance uref"}
+	ErrGetBalanceFailed          = &RpcError{Code: RpcErrorCodeGetBalanceFailed, Message: "failed to get balance"}
+	ErrGetBalanceFailedToExecute = &RpcError{Code: RpcErrorCodeGetBalanceFailedToExecute, Message: "get balance failed to execute"}
+	ErrInvalidDeploy             = &RpcError{Code: RpcErrorCodeInvalidDeploy, Message: "invalid deploy"}
+	ErrNoSuchAccount             = &RpcError{Code: RpcErrorCodeNoSuchAccount, Message: "no such account"}
+	ErrFailedToGetDictionaryURef = &RpcError{Code: RpcErrorCodeFailedToGetDictionaryURef, Message: "failed to get dictionary uref"}
+	ErrNoDictionaryName          = &RpcError{Code: RpcErrorCodeNoDictionaryName, Message: "no dictionary name"}
+	ErrNoSuchMainPurse           = &RpcError{Code: RpcErrorCodeNoSuchMainPurse, Message: "no such main purse"}
+	ErrNoSuchStateRoot           = &RpcError{Code: RpcErrorCodeNoSuchStateRoot, Message: "no such state root"}
+	ErrFunctionIsDisabled        = &RpcError{Code: RpcErrorCodeFunctionIsDisabled, Message: "function is disabled"}
+)
+
+type RpcError struct {
+	Code    int             `json:"code"`
+	Message string          `json:"message"`
+	Data    json.RawMessage `json:"data,omitempty"`
+}
+
+func (e *RpcError) Error() string {
+	if len(e.Data) > 0 {
+		return fmt.Sprintf("rpc call failed, code - %d, message - %s, data - %s", e.Code, e.Message, string(e.Data))
+	}
+	return fmt.Sprintf("rpc call failed, code - %d, message - %s", e.Code, e.Message)
+}
+
+// Is reports whether target is an RpcError with the same code
+func (e *RpcError) Is(target error) bool {
+	t, ok := target.(*RpcError)
+	if !ok {
+		return false
+	}
+	return t.Code == e.Code
+}
+
+// HttpError is returned when the node can't be reached or responds with a non-2xx status code.
+// StatusCode is zero if no response was received, in which case Err holds the transport error
+type HttpError struct {
+	StatusCode int
+	Body       string
+	Err        error
+}
+
+func (e *HttpError) Error() string {
+	if e.StatusCode == 0 {
+		return fmt.Sprintf("failed to make request: %v", e.Err)
+	}
+	if e.Err != nil {
+		return fmt.Sprintf("failed to get response body, status code - %d: %v", e.StatusCode, e.Err)
+	}
+	return fmt.Sprintf("request failed, status code - %d, response - %s", e.StatusCode, e.Body)
+}
+
+func (e *HttpError) Unwrap() error {
+	return e.Err
+}
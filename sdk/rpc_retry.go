@@ -0,0 +1,91 @@
+package sdk
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+)
+
+// RetryPolicy describes how failed rpc calls are retried.
+// Only transport failures, timeouts and 429/5xx responses are retried, json rpc errors returned by the node are not
+type RetryPolicy struct {
+	// MaxAttempts is the total number of attempts per call, including the first one
+	MaxAttempts    int
+	InitialBackoff time.Duration
+	MaxBackoff     time.Duration
+	Multiplier     float64
+	// RetryPutDeploy allows retrying account_put_deploy, which is not idempotent
+	RetryPutDeploy bool
+}
+
+// DefaultRetryPolicy returns a policy with 3 attempts and exponential backoff starting at 100ms
+func DefaultRetryPolicy() RetryPolicy {
+	return RetryPolicy{
+		MaxAttempts:    3,
+		InitialBackoff: 100 * time.Millisecond,
+		MaxBackoff:     2 * time.Second,
+		Multiplier:     2,
+	}
+}
+
+// WithRetryPolicy enables retries of failed calls, rotating through the client endpoints
+func WithRetryPolicy(policy RetryPolicy) RpcClientOption {
+	return func(c *RpcClient) {
+		c.retryPolicy = &policy
+	}
+}
+
+// backoff returns the delay before the attempt following the given zero based attempt
+func (p RetryPolicy) backoff(attempt int) time.Duration {
+	delay := float64(p.InitialBackoff)
+	for i := 0; i < attempt; i++ {
+		delay *= p.Multiplier
+		if p.MaxBackoff > 0 && delay >= float64(p.MaxBackoff) {
+			return p.MaxBackoff
+		}
+	}
+
+	return time.Duration(delay)
+}
+
+func (p RetryPolicy) attempts(method string) int {
+	if method == methodPutDeploy && !p.RetryPutDeploy {
+		return 1
+	}
+
+	if p.MaxAttempts < 1 {
+		return 1
+	}
+
+	return p.MaxAttempts
+}
+
+// isRetryable reports whether err is a node failure worth retrying,
+// cancellation or expiration of the caller context is never retried
+func isRetryable(ctx context.Context, err error) bool {
+	if ctx.Err() != nil {
+		return false
+	}
+
+	var httpErr *HttpError
+	if !errors.As(err, &httpErr) {
+		return false
+	}
+
+	return httpErr.StatusCode == 0 ||
+		httpErr.StatusCode == http.StatusTooManyRequests ||
+		httpErr.StatusCode >= http.StatusInternalServerError
+}
+
+func sleepContext(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
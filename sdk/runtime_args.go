@@ -4,6 +4,7 @@ import (
 	"encoding/hex"
 	"encoding/json"
 	"errors"
+	"fmt"
 
 	"github.com/casper-ecosystem/casper-golang-sdk/serialization"
 	"github.com/casper-ecosystem/casper-golang-sdk/types"
@@ -20,85 +21,102 @@ type Value struct {
 	StringBytes string
 	Optional    *Value
 	Map         *ValueMap
+	// Type is the full type of the value, if set it takes precedence over Tag, Optional and Map
+	Type *types.CLTypeInfo
+	// Parsed is the human readable "parsed" json of the value as written by the node and casper-client, it isn't part of the deploy hash
+	Parsed json.RawMessage
 }
 
-func (v Value) MarshalJSON() ([]byte, error) {
-	data := make(map[string]interface{})
-
-	if v.IsOptional {
-		data["cl_type"] = map[string]interface{}{"Option": v.Optional.Tag.ToString()}
-		//data["bytes"] = hex.EncodeToString(v.Bytes)
-
-		data["bytes"] = v.Optional.StringBytes
-	} else if v.Tag == types.CLTypeMap {
-		data["cl_type"] = map[string]interface{}{
-			"Map": map[string]interface{}{
-				"key":   v.Map.KeyType.ToString(),
-				"value": v.Map.ValueType.ToString(),
-			},
-		}
-		data["bytes"] = v.StringBytes
-	} else {
-		if v.Tag == types.CLTypeByteArray {
-			data["cl_type"] = map[string]interface{}{"ByteArray": len(v.StringBytes) / 2}
-		} else {
-			data["cl_type"] = v.Tag.ToString()
-		}
+// NewValue creates the argument value of v with its full type
+func NewValue(v types.CLValue) (Value, error) {
+	clType, err := v.FullType()
+	if err != nil {
+		return Value{}, err
+	}
 
-		data["bytes"] = v.StringBytes
+	marshaledValue, err := serialization.Marshal(v)
+	if err != nil {
+		return Value{}, err
 	}
 
-	return json.Marshal(data)
+	return newValue(clType, marshaledValue), nil
 }
 
-func (v Value) ToBytes() []uint8 {
-	res := make([]uint8, 0)
+// newValue creates the argument value of the encoded value of type clType, setting the legacy fields as well
+func newValue(clType types.CLTypeInfo, marshaledValue []byte) Value {
+	value := Value{
+		Tag:  clType.Type,
+		Type: &clType,
+	}
 
-	var bytesSerialized []byte
-	var encodedBytesSerialized []byte
-	var err error
+	switch clType.Type {
+	case types.CLTypeOption:
+		value.IsOptional = true
+		value.Optional = &Value{Tag: clType.Inner.Type, StringBytes: hex.EncodeToString(marshaledValue)}
+	case types.CLTypeMap:
+		value.Map = &ValueMap{KeyType: clType.Key.Type, ValueType: clType.Value.Type}
+		value.StringBytes = hex.EncodeToString(marshaledValue)
+	default:
+		value.StringBytes = hex.EncodeToString(marshaledValue)
+	}
 
-	if v.IsOptional {
-		decodeString, err := hex.DecodeString(v.Optional.StringBytes)
-		if err != nil {
-			return nil
-		}
-		marshaledOptional, err2 := serialization.Marshal(decodeString)
-		if err2 != nil {
-			return nil
-		}
+	return value
+}
 
-		res = append(res, marshaledOptional...)
-		//bytesSerialized = v.Bytes
-		//encodedBytesSerialized = v.Bytes
-	} else {
-		bytesSerialized, err = hex.DecodeString(v.StringBytes)
-		if err != nil {
-			return nil
-		}
-		encodedBytesSerialized, err = serialization.Marshal(bytesSerialized)
-		if err != nil {
-			return nil
-		}
+// CLType returns the full type of the value, derived from Tag, Optional and Map if Type is not set
+func (v Value) CLType() types.CLTypeInfo {
+	if v.Type != nil {
+		return *v.Type
 	}
 
-	res = append(res, encodedBytesSerialized...)
+	switch {
+	case v.IsOptional:
+		return types.OptionCLType(types.SimpleCLType(v.Optional.Tag))
+	case v.Tag == types.CLTypeMap && v.Map != nil:
+		return types.MapCLType(types.SimpleCLType(v.Map.KeyType), types.SimpleCLType(v.Map.ValueType))
+	case v.Tag == types.CLTypeByteArray:
+		return types.ByteArrayCLType(uint32(len(v.StringBytes) / 2))
+	}
 
-	res = append(res, uint8(v.Tag))
+	return types.SimpleCLType(v.Tag)
+}
 
-	if v.Tag == types.CLTypeByteArray {
-		lenByteArray, err := serialization.Marshal(int32(len(bytesSerialized)))
+// hexBytes returns the hex encoded value, which is stored in Optional for option values
+func (v Value) hexBytes() string {
+	if v.IsOptional && v.Optional != nil {
+		return v.Optional.StringBytes
+	}
+	return v.StringBytes
+}
 
-		if err != nil {
-			return nil
-		}
+func (v Value) MarshalJSON() ([]byte, error) {
+	// the fields are written in the order of the node and casper-client
+	data := struct {
+		CLType types.CLTypeInfo `json:"cl_type"`
+		Bytes  string           `json:"bytes"`
+		Parsed json.RawMessage  `json:"parsed,omitempty"`
+	}{v.CLType(), v.hexBytes(), v.Parsed}
+
+	return json.Marshal(data)
+}
+
+func (v Value) ToBytes() []uint8 {
+	bytesSerialized, err := hex.DecodeString(v.hexBytes())
+	if err != nil {
+		return nil
+	}
 
-		res = append(res, lenByteArray...)
-	} else if v.Tag == types.CLTypeOption {
-		res = append(res, uint8(v.Optional.Tag))
+	res, err := serialization.Marshal(bytesSerialized)
+	if err != nil {
+		return nil
 	}
 
-	return res
+	clTypeBytes, err := v.CLType().ToBytes()
+	if err != nil {
+		return nil
+	}
+
+	return append(res, clTypeBytes...)
 }
 
 type RuntimeArgs struct {
@@ -127,55 +145,48 @@ func ParseRuntimeArgs(args []interface{}) (RuntimeArgs, error) {
 			return RuntimeArgs{}, errors.New("'bytes' key doesn't exist, invalid json")
 		}
 		if valueParsed["cl_type"] == nil {
-			return RuntimeArgs{}, errors.New("'bytes' key doesn't exist, invalid json")
+			return RuntimeArgs{}, errors.New("'cl_type' key doesn't exist, invalid json")
+		}
+
+		stringBytes, ok := valueParsed["bytes"].(string)
+		if !ok {
+			return RuntimeArgs{}, errors.New("'bytes' is not a string, invalid json")
 		}
 
-		cl_type, ok := valueParsed["cl_type"].(string)
+		clTypeJSON, err := json.Marshal(valueParsed["cl_type"])
+		if err != nil {
+			return RuntimeArgs{}, errors.New("failed parse cl_type")
+		}
 
-		var value Value
+		var clType types.CLTypeInfo
+		if err := json.Unmarshal(clTypeJSON, &clType); err != nil {
+			return RuntimeArgs{}, fmt.Errorf("failed parse cl_type: %w", err)
+		}
 
-		if !ok {
-			cl_type, ok := valueParsed["cl_type"].(map[string]interface{})
-			if !ok {
-				return RuntimeArgs{}, errors.New("failed parse cl_type")
+		value := Value{
+			Tag:         clType.Type,
+			StringBytes: stringBytes,
+			Type:        &clType,
+		}
+
+		if parsed, ok := valueParsed["parsed"]; ok {
+			if value.Parsed, err = json.Marshal(parsed); err != nil {
+				return RuntimeArgs{}, fmt.Errorf("failed parse parsed: %w", err)
 			}
+		}
 
-			if cl_type["Option"] != nil {
-				value = Value{
-					Tag:        types.CLTypeOption,
-					IsOptional: true,
-					Optional: &Value{
-						Tag:         types.FromString(cl_type["Option"].(string)),
-						StringBytes: valueParsed["bytes"].(string),
-					},
-				}
-			} else if cl_type["ByteArray"] != nil {
-				value = Value{
-					Tag:         types.CLTypeByteArray,
-					StringBytes: valueParsed["bytes"].(string),
-				}
-			} else if cl_type["Map"] != nil {
-				mapTypes, ok := cl_type["Map"].(map[string]interface{})
-
-				if !ok {
-					return RuntimeArgs{}, errors.New("unable to unmarshal, not map types")
-				}
-
-				value = Value{
-					Tag:         types.CLTypeMap,
-					StringBytes: valueParsed["bytes"].(string),
-					Map: &ValueMap{
-						KeyType:   types.FromString(mapTypes["key"].(string)),
-						ValueType: types.FromString(mapTypes["value"].(string)),
-					},
-				}
-			} else {
-				return RuntimeArgs{}, errors.New("unknown type")
+		switch clType.Type {
+		case types.CLTypeOption:
+			value.IsOptional = true
+			value.StringBytes = ""
+			value.Optional = &Value{
+				Tag:         clType.Inner.Type,
+				StringBytes: stringBytes,
 			}
-		} else {
-			value = Value{
-				Tag:         types.FromString(cl_type),
-				StringBytes: valueParsed["bytes"].(string),
+		case types.CLTypeMap:
+			value.Map = &ValueMap{
+				KeyType:   clType.Key.Type,
+				ValueType: clType.Value.Type,
 			}
 		}
 
@@ -0,0 +1,570 @@
+package sdk
+
+import (
+	"bytes"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"math/big"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/casper-ecosystem/casper-golang-sdk/keypair"
+	"github.com/casper-ecosystem/casper-golang-sdk/serialization"
+	"github.com/casper-ecosystem/casper-golang-sdk/types"
+)
+
+// casperTag is the struct tag of the fields handled by MarshalRuntimeArgs, UnmarshalRuntimeArgs and UnmarshalStruct.
+// The tag holds the argument name and optionally its cl type, e.g. `casper:"amount,u512"`.
+// Without a type the cl type is derived from the go type, fields without tag or tagged with "-" are skipped
+const casperTag = "casper"
+
+// casperTypeHints are the cl types which can be used in the casper tag
+var casperTypeHints = map[string]types.CLType{
+	"bool":       types.CLTypeBool,
+	"i32":        types.CLTypeI32,
+	"i64":        types.CLTypeI64,
+	"u8":         types.CLTypeU8,
+	"u32":        types.CLTypeU32,
+	"u64":        types.CLTypeU64,
+	"u128":       types.CLTypeU128,
+	"u256":       types.CLTypeU256,
+	"u512":       types.CLTypeU512,
+	"string":     types.CLTypeString,
+	"key":        types.CLTypeKey,
+	"uref":       types.CLTypeURef,
+	"list":       types.CLTypeList,
+	"byte_array": types.CLTypeByteArray,
+	"map":        types.CLTypeMap,
+	"public_key": types.CLTypePublicKey,
+}
+
+var (
+	bigIntType    = reflect.TypeOf(big.Int{})
+	u128Type      = reflect.TypeOf(serialization.U128{})
+	u256Type      = reflect.TypeOf(serialization.U256{})
+	u512Type      = reflect.TypeOf(serialization.U512{})
+	keyType       = reflect.TypeOf(types.Key{})
+	urefType      = reflect.TypeOf(types.URef{})
+	publicKeyType = reflect.TypeOf(keypair.PublicKey{})
+)
+
+// structField is a tagged field of a struct along with its cl type
+type structField struct {
+	index  int
+	name   string
+	clType types.CLTypeInfo
+}
+
+// optional reports whether the field is a pointer, which may be missing when unmarshalling
+func (f structField) optional() bool {
+	return f.clType.Type == types.CLTypeOption
+}
+
+// MarshalRuntimeArgs creates the runtime args of the tagged fields of the struct v, in the order of the fields.
+// Pointer fields are encoded as Option, big.Int fields as U512 unless the tag says u128 or u256, e.g.
+//
+//	type TransferArgs struct {
+//		Amount    big.Int           `casper:"amount,u512"`
+//		Recipient types.Key         `casper:"recipient,key"`
+//		ID        *uint64           `casper:"id"`
+//		Meta      map[string]string `casper:"meta,map"`
+//	}
+func MarshalRuntimeArgs(v interface{}) (RuntimeArgs, error) {
+	rv := reflect.Indirect(reflect.ValueOf(v))
+	if rv.Kind() != reflect.Struct {
+		return RuntimeArgs{}, fmt.Errorf("expected a struct, got %T", v)
+	}
+
+	fields, err := structFields(rv.Type())
+	if err != nil {
+		return RuntimeArgs{}, err
+	}
+
+	args := make(map[string]Value, len(fields))
+	order := make([]string, 0, len(fields))
+
+	for _, field := range fields {
+		var buf bytes.Buffer
+		if err := encodeArg(serialization.NewEncoder(&buf), rv.Field(field.index), field.clType); err != nil {
+			return RuntimeArgs{}, fmt.Errorf("failed to encode %q: %w", field.name, err)
+		}
+
+		args[field.name] = newValue(field.clType, buf.Bytes())
+		order = append(order, field.name)
+	}
+
+	return *NewRunTimeArgs(args, order), nil
+}
+
+// UnmarshalRuntimeArgs decodes the runtime args into the tagged fields of the struct v points to.
+// Missing args are an error unless the field is a pointer, args without field are ignored
+func UnmarshalRuntimeArgs(args RuntimeArgs, v interface{}) error {
+	rv, fields, err := structPointer(v)
+	if err != nil {
+		return err
+	}
+
+	for _, field := range fields {
+		value, ok := args.Args[field.name]
+		if !ok {
+			if field.optional() {
+				continue
+			}
+			return fmt.Errorf("missing argument %q", field.name)
+		}
+
+		clType := value.CLType()
+		if !clType.Equal(field.clType) {
+			return fmt.Errorf("argument %q: %w: expected %s, got %s", field.name, types.ErrCLTypeMismatch, field.clType, clType)
+		}
+
+		data, err := hex.DecodeString(value.hexBytes())
+		if err != nil {
+			return fmt.Errorf("argument %q: invalid bytes: %w", field.name, err)
+		}
+
+		clValue, err := types.DecodeCLValue(data, clType)
+		if err != nil {
+			return fmt.Errorf("failed to decode argument %q: %w", field.name, err)
+		}
+
+		if err := assignCLValue(rv.Field(field.index), clValue, clType); err != nil {
+			return fmt.Errorf("argument %q: %w", field.name, err)
+		}
+	}
+
+	return nil
+}
+
+// UnmarshalStruct decodes a Map with String keys or a tuple into the tagged fields of the struct v points to.
+// Map entries are matched by the name in the tag, tuple elements by the order of the fields
+func UnmarshalStruct(value types.CLValue, v interface{}) error {
+	rv, fields, err := structPointer(v)
+	if err != nil {
+		return err
+	}
+
+	clType, err := value.FullType()
+	if err != nil {
+		return err
+	}
+
+	switch clType.Type {
+	case types.CLTypeMap:
+		if clType.Key.Type != types.CLTypeString {
+			return fmt.Errorf("%w: expected a map with String keys, got %s", types.ErrCLTypeMismatch, clType)
+		}
+
+		entries, err := value.AsMap()
+		if err != nil {
+			return err
+		}
+
+		for _, field := range fields {
+			entry, ok := entries[field.name]
+			if !ok {
+				if field.optional() {
+					continue
+				}
+				return fmt.Errorf("missing map entry %q", field.name)
+			}
+
+			if !clType.Value.Equal(field.clType) {
+				return fmt.Errorf("map entry %q: %w: expected %s, got %s", field.name, types.ErrCLTypeMismatch, field.clType, clType.Value)
+			}
+			if err := assignCLValue(rv.Field(field.index), entry, field.clType); err != nil {
+				return fmt.Errorf("map entry %q: %w", field.name, err)
+			}
+		}
+	case types.CLTypeTuple1, types.CLTypeTuple2, types.CLTypeTuple3:
+		elements, err := value.AsTuple()
+		if err != nil {
+			return err
+		}
+		if len(elements) != len(fields) {
+			return fmt.Errorf("%s has %d elements, the struct %d fields", clType.Type.ToString(), len(elements), len(fields))
+		}
+
+		for i, field := range fields {
+			if !clType.Tuple[i].Equal(field.clType) {
+				return fmt.Errorf("tuple element %d: %w: expected %s, got %s", i, types.ErrCLTypeMismatch, field.clType, clType.Tuple[i])
+			}
+			if err := assignCLValue(rv.Field(field.index), elements[i], field.clType); err != nil {
+				return fmt.Errorf("tuple element %d: %w", i, err)
+			}
+		}
+	default:
+		return fmt.Errorf("%w: expected Map or a tuple, got %s", types.ErrCLTypeMismatch, clType)
+	}
+
+	return nil
+}
+
+// structPointer returns the struct v points to along with its tagged fields
+func structPointer(v interface{}) (reflect.Value, []structField, error) {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Ptr || rv.IsNil() || rv.Elem().Kind() != reflect.Struct {
+		return reflect.Value{}, nil, fmt.Errorf("expected a pointer to a struct, got %T", v)
+	}
+
+	fields, err := structFields(rv.Elem().Type())
+	return rv.Elem(), fields, err
+}
+
+func structFields(t reflect.Type) ([]structField, error) {
+	var fields []structField
+	names := make(map[string]bool)
+
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		tag, ok := field.Tag.Lookup(casperTag)
+		if !ok || tag == "-" || field.PkgPath != "" {
+			continue
+		}
+
+		name, hint := tag, ""
+		if comma := strings.Index(tag, ","); comma >= 0 {
+			name, hint = tag[:comma], tag[comma+1:]
+		}
+		if name == "" {
+			name = field.Name
+		}
+		if names[name] {
+			return nil, fmt.Errorf("duplicate argument name %q", name)
+		}
+		names[name] = true
+
+		clType, err := goCLType(field.Type, hint)
+		if err != nil {
+			return nil, fmt.Errorf("field %s: %w", field.Name, err)
+		}
+
+		fields = append(fields, structField{index: i, name: name, clType: clType})
+	}
+
+	return fields, nil
+}
+
+// goCLType returns the cl type of the go type, the hint is the type from the tag and applies to the value behind pointers
+func goCLType(t reflect.Type, hint string) (types.CLTypeInfo, error) {
+	if t.Kind() == reflect.Ptr {
+		inner, err := goCLType(t.Elem(), hint)
+		if err != nil {
+			return types.CLTypeInfo{}, err
+		}
+		return types.OptionCLType(inner), nil
+	}
+
+	clType, err := inferCLType(t, hint)
+	if err != nil {
+		return types.CLTypeInfo{}, err
+	}
+
+	if hint != "" {
+		expected, ok := casperTypeHints[hint]
+		if !ok {
+			return types.CLTypeInfo{}, fmt.Errorf("unknown cl type %q", hint)
+		}
+		if expected != clType.Type {
+			return types.CLTypeInfo{}, fmt.Errorf("cl type %q doesn't match the go type %s", hint, t)
+		}
+	}
+
+	return clType, nil
+}
+
+func inferCLType(t reflect.Type, hint string) (types.CLTypeInfo, error) {
+	switch t {
+	case bigIntType:
+		switch hint {
+		case "u128":
+			return types.SimpleCLType(types.CLTypeU128), nil
+		case "u256":
+			return types.SimpleCLType(types.CLTypeU256), nil
+		}
+		return types.SimpleCLType(types.CLTypeU512), nil
+	case u128Type:
+		return types.SimpleCLType(types.CLTypeU128), nil
+	case u256Type:
+		return types.SimpleCLType(types.CLTypeU256), nil
+	case u512Type:
+		return types.SimpleCLType(types.CLTypeU512), nil
+	case keyType:
+		return types.SimpleCLType(types.CLTypeKey), nil
+	case urefType:
+		return types.SimpleCLType(types.CLTypeURef), nil
+	case publicKeyType:
+		return types.SimpleCLType(types.CLTypePublicKey), nil
+	}
+
+	switch t.Kind() {
+	case reflect.Bool:
+		return types.SimpleCLType(types.CLTypeBool), nil
+	case reflect.Int32:
+		return types.SimpleCLType(types.CLTypeI32), nil
+	case reflect.Int64:
+		return types.SimpleCLType(types.CLTypeI64), nil
+	case reflect.Uint8:
+		return types.SimpleCLType(types.CLTypeU8), nil
+	case reflect.Uint32:
+		return types.SimpleCLType(types.CLTypeU32), nil
+	case reflect.Uint64:
+		return types.SimpleCLType(types.CLTypeU64), nil
+	case reflect.String:
+		return types.SimpleCLType(types.CLTypeString), nil
+	case reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return types.ByteArrayCLType(uint32(t.Len())), nil
+		}
+	case reflect.Slice:
+		inner, err := goCLType(t.Elem(), "")
+		if err != nil {
+			return types.CLTypeInfo{}, err
+		}
+		return types.ListCLType(inner), nil
+	case reflect.Map:
+		key, err := goCLType(t.Key(), "")
+		if err != nil {
+			return types.CLTypeInfo{}, err
+		}
+		value, err := goCLType(t.Elem(), "")
+		if err != nil {
+			return types.CLTypeInfo{}, err
+		}
+		return types.MapCLType(key, value), nil
+	}
+
+	return types.CLTypeInfo{}, fmt.Errorf("unsupported go type %s", t)
+}
+
+// encodeArg encodes v as a value of type clType. Options, lists and maps are walked here, so that pointers become
+// options and map entries are written in the key order of the node, the other values are written by the encoder
+func encodeArg(enc *serialization.Encoder, v reflect.Value, clType types.CLTypeInfo) error {
+	switch clType.Type {
+	case types.CLTypeOption:
+		if v.IsNil() {
+			_, err := enc.EncodeBool(false)
+			return err
+		}
+		if _, err := enc.EncodeBool(true); err != nil {
+			return err
+		}
+		return encodeArg(enc, v.Elem(), *clType.Inner)
+	case types.CLTypeList:
+		if _, err := enc.EncodeUInt32(uint32(v.Len())); err != nil {
+			return err
+		}
+		for i := 0; i < v.Len(); i++ {
+			if err := encodeArg(enc, v.Index(i), *clType.Inner); err != nil {
+				return err
+			}
+		}
+		return nil
+	case types.CLTypeMap:
+		return encodeMapArg(enc, v, clType)
+	case types.CLTypeU128, types.CLTypeU256, types.CLTypeU512:
+		if err := checkBigInt(v, clType.Type); err != nil {
+			return err
+		}
+	}
+
+	_, err := enc.Encode(v.Interface())
+	return err
+}
+
+// encodeMapArg writes the entries sorted by key, strings and integers in their natural order, other keys by their bytes
+func encodeMapArg(enc *serialization.Encoder, v reflect.Value, clType types.CLTypeInfo) error {
+	keys := v.MapKeys()
+	encodedKeys := make([][]byte, len(keys))
+	for i, key := range keys {
+		var buf bytes.Buffer
+		if err := encodeArg(serialization.NewEncoder(&buf), key, *clType.Key); err != nil {
+			return err
+		}
+		encodedKeys[i] = buf.Bytes()
+	}
+
+	order := make([]int, len(keys))
+	for i := range order {
+		order[i] = i
+	}
+	sort.Slice(order, func(i, j int) bool {
+		a, b := keys[order[i]], keys[order[j]]
+		switch a.Kind() {
+		case reflect.String:
+			return a.String() < b.String()
+		case reflect.Int32, reflect.Int64:
+			return a.Int() < b.Int()
+		case reflect.Uint8, reflect.Uint32, reflect.Uint64:
+			return a.Uint() < b.Uint()
+		}
+		return bytes.Compare(encodedKeys[order[i]], encodedKeys[order[j]]) < 0
+	})
+
+	if _, err := enc.EncodeUInt32(uint32(len(keys))); err != nil {
+		return err
+	}
+	for _, i := range order {
+		if _, err := enc.EncodeFixedByteArray(encodedKeys[i]); err != nil {
+			return err
+		}
+		if err := encodeArg(enc, v.MapIndex(keys[i]), *clType.Value); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// checkBigInt checks that the big.Int, U128, U256 or U512 value fits into the cl type
+func checkBigInt(v reflect.Value, clType types.CLType) error {
+	var i big.Int
+	if v.Type() == bigIntType {
+		i = v.Interface().(big.Int)
+	} else {
+		i = v.Field(0).Interface().(big.Int)
+	}
+
+	bits := map[types.CLType]int{types.CLTypeU128: 128, types.CLTypeU256: 256, types.CLTypeU512: 512}[clType]
+	if i.Sign() < 0 || i.BitLen() > bits {
+		return fmt.Errorf("%s is out of range of %s", i.String(), clType.ToString())
+	}
+	return nil
+}
+
+// assignCLValue sets dest to the value of type clType, which has to match the go type of dest
+func assignCLValue(dest reflect.Value, value types.CLValue, clType types.CLTypeInfo) error {
+	switch clType.Type {
+	case types.CLTypeOption:
+		inner, err := value.AsOption()
+		if err != nil {
+			return err
+		}
+		if inner == nil {
+			dest.Set(reflect.Zero(dest.Type()))
+			return nil
+		}
+		elem := reflect.New(dest.Type().Elem())
+		if err := assignCLValue(elem.Elem(), *inner, *clType.Inner); err != nil {
+			return err
+		}
+		dest.Set(elem)
+	case types.CLTypeList:
+		elements, err := value.AsList()
+		if err != nil {
+			return err
+		}
+		list := reflect.MakeSlice(dest.Type(), len(elements), len(elements))
+		for i, element := range elements {
+			if err := assignCLValue(list.Index(i), element, *clType.Inner); err != nil {
+				return err
+			}
+		}
+		dest.Set(list)
+	case types.CLTypeMap:
+		entries, err := value.AsMap()
+		if err != nil {
+			return err
+		}
+		m := reflect.MakeMapWithSize(dest.Type(), len(entries))
+		for k, entry := range entries {
+			key := reflect.New(dest.Type().Key()).Elem()
+			if err := assignMapKey(key, k, *clType.Key); err != nil {
+				return err
+			}
+			elem := reflect.New(dest.Type().Elem()).Elem()
+			if err := assignCLValue(elem, entry, *clType.Value); err != nil {
+				return err
+			}
+			m.SetMapIndex(key, elem)
+		}
+		dest.Set(m)
+	case types.CLTypeByteArray:
+		data, err := value.AsBytes()
+		if err != nil {
+			return err
+		}
+		if len(data) != dest.Len() {
+			return fmt.Errorf("expected %d bytes, got %d", dest.Len(), len(data))
+		}
+		reflect.Copy(dest, reflect.ValueOf(data))
+	case types.CLTypeBool:
+		b, err := value.AsBool()
+		if err != nil {
+			return err
+		}
+		dest.SetBool(b)
+	case types.CLTypeString:
+		s, err := value.AsString()
+		if err != nil {
+			return err
+		}
+		dest.SetString(s)
+	case types.CLTypeI32, types.CLTypeI64:
+		i, err := value.AsBigInt()
+		if err != nil {
+			return err
+		}
+		dest.SetInt(i.Int64())
+	case types.CLTypeU8, types.CLTypeU32, types.CLTypeU64:
+		i, err := value.AsUint64()
+		if err != nil {
+			return err
+		}
+		dest.SetUint(i)
+	case types.CLTypeU128, types.CLTypeU256, types.CLTypeU512:
+		i, err := value.AsBigInt()
+		if err != nil {
+			return err
+		}
+		if dest.Type() == bigIntType {
+			dest.Set(reflect.ValueOf(*i))
+		} else {
+			dest.Field(0).Set(reflect.ValueOf(*i))
+		}
+	case types.CLTypeKey:
+		key, err := value.AsKey()
+		if err != nil {
+			return err
+		}
+		dest.Set(reflect.ValueOf(key))
+	case types.CLTypeURef:
+		uref, err := value.AsURef()
+		if err != nil {
+			return err
+		}
+		dest.Set(reflect.ValueOf(uref))
+	case types.CLTypePublicKey:
+		publicKey, err := value.AsPublicKey()
+		if err != nil {
+			return err
+		}
+		dest.Set(reflect.ValueOf(publicKey))
+	default:
+		return fmt.Errorf("unsupported cl type %s", clType)
+	}
+
+	return nil
+}
+
+// assignMapKey sets dest to the map key as returned by CLValue.AsMap, String keys as they are and other keys hex encoded
+func assignMapKey(dest reflect.Value, key string, clType types.CLTypeInfo) error {
+	if clType.Type == types.CLTypeString {
+		dest.SetString(key)
+		return nil
+	}
+
+	data, err := hex.DecodeString(key)
+	if err != nil {
+		return errors.New("invalid map key")
+	}
+
+	value, err := types.DecodeCLValue(data, clType)
+	if err != nil {
+		return fmt.Errorf("failed to decode map key: %w", err)
+	}
+
+	return assignCLValue(dest, value, clType)
+}
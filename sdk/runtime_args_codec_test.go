@@ -0,0 +1,200 @@
+package sdk
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/casper-ecosystem/casper-golang-sdk/types"
+	"github.com/stretchr/testify/assert"
+)
+
+type testTransferArgs struct {
+	Amount    big.Int           `casper:"amount,u512"`
+	Recipient types.Key         `casper:"recipient,key"`
+	ID        *uint64           `casper:"id"`
+	Meta      map[string]string `casper:"meta,map"`
+	Ignored   string
+}
+
+func testAccountKey() types.Key {
+	key := types.Key{Type: types.KeyTypeAccount}
+	for i := range key.Account {
+		key.Account[i] = 1
+	}
+	return key
+}
+
+func TestMarshalRuntimeArgs(t *testing.T) {
+	args, err := MarshalRuntimeArgs(testTransferArgs{
+		Amount:    *big.NewInt(1000),
+		Recipient: testAccountKey(),
+		Meta:      map[string]string{"b": "2", "a": "1"},
+		Ignored:   "ignored",
+	})
+	assert.NoError(t, err)
+
+	assert.Equal(t, []string{"amount", "recipient", "id", "meta"}, args.KeyOrder)
+	assert.Equal(t, "U512", args.Args["amount"].CLType().String())
+	assert.Equal(t, "02e803", args.Args["amount"].StringBytes)
+	assert.Equal(t, "00"+strings.Repeat("01", 32), args.Args["recipient"].StringBytes)
+	assert.Equal(t, "Option<U64>", args.Args["id"].CLType().String())
+	assert.Equal(t, "00", args.Args["id"].hexBytes())
+	// map entries are sorted by key
+	assert.Equal(t, "02000000"+"0100000061"+"0100000031"+"0100000062"+"0100000032", args.Args["meta"].StringBytes)
+
+	metaJSON, err := json.Marshal(args.Args["meta"])
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"bytes":"020000000100000061010000003101000000620100000032","cl_type":{"Map":{"key":"String","value":"String"}}}`, string(metaJSON))
+}
+
+func TestRuntimeArgs_RoundTrip(t *testing.T) {
+	id := uint64(5)
+	original := testTransferArgs{
+		Amount:    *big.NewInt(2500000000),
+		Recipient: testAccountKey(),
+		ID:        &id,
+		Meta:      map[string]string{"memo": "payout"},
+	}
+
+	args, err := MarshalRuntimeArgs(&original)
+	assert.NoError(t, err)
+	assert.Equal(t, "010500000000000000", args.Args["id"].hexBytes())
+
+	var decoded testTransferArgs
+	assert.NoError(t, UnmarshalRuntimeArgs(args, &decoded))
+
+	assert.Equal(t, 0, original.Amount.Cmp(&decoded.Amount))
+	assert.Equal(t, original.Recipient, decoded.Recipient)
+	assert.Equal(t, original.ID, decoded.ID)
+	assert.Equal(t, original.Meta, decoded.Meta)
+}
+
+func TestUnmarshalRuntimeArgs_ParsedArgs(t *testing.T) {
+	var parsed []interface{}
+	err := json.Unmarshal([]byte(`[
+		["amount", {"bytes": "0400f90295", "cl_type": "U512"}],
+		["recipient", {"bytes": "00`+strings.Repeat("01", 32)+`", "cl_type": "Key"}],
+		["meta", {"bytes": "00000000", "cl_type": {"Map": {"key": "String", "value": "String"}}}]
+	]`), &parsed)
+	assert.NoError(t, err)
+
+	args, err := ParseRuntimeArgs(parsed)
+	assert.NoError(t, err)
+
+	var decoded testTransferArgs
+	assert.NoError(t, UnmarshalRuntimeArgs(args, &decoded))
+	assert.Equal(t, "2500000000", decoded.Amount.String())
+	assert.Equal(t, testAccountKey(), decoded.Recipient)
+	assert.Nil(t, decoded.ID)
+	assert.Empty(t, decoded.Meta)
+}
+
+func TestUnmarshalRuntimeArgs_Errors(t *testing.T) {
+	args, err := MarshalRuntimeArgs(struct {
+		Amount big.Int `casper:"amount,u256"`
+	}{Amount: *big.NewInt(1)})
+	assert.NoError(t, err)
+
+	var decoded testTransferArgs
+	err = UnmarshalRuntimeArgs(args, &decoded)
+	assert.True(t, errors.Is(err, types.ErrCLTypeMismatch))
+
+	args.Args = map[string]Value{}
+	err = UnmarshalRuntimeArgs(args, &decoded)
+	assert.EqualError(t, err, `missing argument "amount"`)
+
+	err = UnmarshalRuntimeArgs(args, decoded)
+	assert.Error(t, err)
+}
+
+func TestMarshalRuntimeArgs_Errors(t *testing.T) {
+	_, err := MarshalRuntimeArgs(struct {
+		Count int `casper:"count"`
+	}{})
+	assert.Error(t, err)
+
+	_, err = MarshalRuntimeArgs(struct {
+		Name string `casper:"name,u512"`
+	}{})
+	assert.Error(t, err)
+
+	_, err = MarshalRuntimeArgs(struct {
+		Amount big.Int `casper:"amount,u512"`
+	}{Amount: *big.NewInt(-1)})
+	assert.Error(t, err)
+
+	_, err = MarshalRuntimeArgs("amount")
+	assert.Error(t, err)
+}
+
+func TestUnmarshalStruct(t *testing.T) {
+	type tupleStruct struct {
+		Name  string  `casper:"name"`
+		Count uint32  `casper:"count"`
+		Owner *[]byte `casper:"owner"`
+	}
+
+	data, _ := hex.DecodeString("0300000061626307000000" + "01" + "020000000a0b")
+	tuple, err := types.DecodeCLValue(data, types.TupleCLType(
+		types.SimpleCLType(types.CLTypeString),
+		types.SimpleCLType(types.CLTypeU32),
+		types.OptionCLType(types.ListCLType(types.SimpleCLType(types.CLTypeU8))),
+	))
+	assert.NoError(t, err)
+
+	var fromTuple tupleStruct
+	assert.NoError(t, UnmarshalStruct(tuple, &fromTuple))
+	assert.Equal(t, "abc", fromTuple.Name)
+	assert.Equal(t, uint32(7), fromTuple.Count)
+	assert.Equal(t, []byte{0x0a, 0x0b}, *fromTuple.Owner)
+
+	type mapStruct struct {
+		Height uint64  `casper:"height"`
+		Era    uint64  `casper:"era"`
+		Extra  *uint64 `casper:"extra"`
+	}
+
+	data, _ = hex.DecodeString("02000000" + "03000000657261" + "0300000000000000" + "06000000686569676874" + "0700000000000000")
+	m, err := types.DecodeCLValue(data, types.MapCLType(types.SimpleCLType(types.CLTypeString), types.SimpleCLType(types.CLTypeU64)))
+	assert.NoError(t, err)
+
+	var fromMap mapStruct
+	assert.NoError(t, UnmarshalStruct(m, &fromMap))
+	assert.Equal(t, mapStruct{Height: 7, Era: 3}, fromMap)
+
+	var wrongOrder struct {
+		Count uint32  `casper:"count"`
+		Name  string  `casper:"name"`
+		Owner *[]byte `casper:"owner"`
+	}
+	err = UnmarshalStruct(tuple, &wrongOrder)
+	assert.True(t, errors.Is(err, types.ErrCLTypeMismatch))
+}
+
+func TestTransferContract_MakeArgs(t *testing.T) {
+	accountHash := strings.Repeat("01", 32)
+	args := TransferContract{}.MakeArgs(accountHash, *big.NewInt(1000))
+
+	assert.Equal(t, []string{"account", "amount"}, args.KeyOrder)
+	assert.Equal(t, types.CLTypeKey, args.Args["account"].Tag)
+	assert.Equal(t, "00"+accountHash, args.Args["account"].StringBytes)
+	assert.Equal(t, types.CLTypeU512, args.Args["amount"].Tag)
+	assert.Equal(t, "02e803", args.Args["amount"].StringBytes)
+
+	assert.Equal(t, RuntimeArgs{}, TransferContract{}.MakeArgs("invalid", *big.NewInt(1000)))
+}
+
+func TestFaucetContract_MakeArgs(t *testing.T) {
+	accountHash := strings.Repeat("01", 32)
+	args := FaucetContract{}.MakeArgs(types.AccountHashPrefix + accountHash)
+
+	assert.Equal(t, []string{"account"}, args.KeyOrder)
+	assert.Equal(t, "00"+accountHash, args.Args["account"].StringBytes)
+	assert.Equal(t, args, FaucetContract{}.MakeArgs(accountHash))
+
+	assert.Equal(t, RuntimeArgs{}, FaucetContract{}.MakeArgs("hash-"+accountHash))
+}
@@ -3,6 +3,10 @@ package sdk
 import (
 	"encoding/hex"
 	"encoding/json"
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
 	"time"
 )
 
@@ -28,10 +32,39 @@ func (h *Hash) UnmarshalJSON(data []byte) error {
 	return nil
 }
 
+// BigInt is a big.Int encoded as a decimal string in json, like the U128, U256 and U512 amounts of the node
+type BigInt struct {
+	big.Int
+}
+
+func NewBigInt(i *big.Int) BigInt {
+	var result BigInt
+	result.Set(i)
+	return result
+}
+
+func (b BigInt) MarshalJSON() ([]byte, error) {
+	return json.Marshal(b.String())
+}
+
+func (b *BigInt) UnmarshalJSON(data []byte) error {
+	var dataString string
+
+	if err := json.Unmarshal(data, &dataString); err != nil {
+		return err
+	}
+
+	if _, ok := b.SetString(dataString, 10); !ok {
+		return fmt.Errorf("invalid integer: %q", dataString)
+	}
+
+	return nil
+}
+
 type Timestamp int64
 
 func (t Timestamp) MarshalJSON() ([]byte, error) {
-	return json.Marshal(time.Unix(0, int64(t)*1000000).UTC().Format("2006-01-02T15:04:05.999Z"))
+	return json.Marshal(time.Unix(0, int64(t)*1000000).UTC().Format("2006-01-02T15:04:05.000Z"))
 }
 
 func (t *Timestamp) UnmarshalJSON(data []byte) error {
@@ -52,8 +85,9 @@ func (t *Timestamp) UnmarshalJSON(data []byte) error {
 
 type Duration time.Duration
 
+// MarshalJSON encodes the duration in the humantime format of the node, e.g. "30m" or "1day 2h"
 func (d Duration) MarshalJSON() ([]byte, error) {
-	return json.Marshal(time.Duration(d * 1000000).String())
+	return json.Marshal(formatHumanDuration(time.Duration(d) * time.Millisecond))
 }
 
 func (d *Duration) UnmarshalJSON(data []byte) error {
@@ -63,12 +97,134 @@ func (d *Duration) UnmarshalJSON(data []byte) error {
 		return err
 	}
 
-	duration, err := time.ParseDuration(dataString)
+	// the humantime format covers go durations without fractions like "30m0s" as well
+	duration, err := parseHumanDuration(dataString)
 	if err != nil {
 		return err
 	}
 
-	*d = Duration(duration / 1000000)
+	*d = Duration(duration / time.Millisecond)
 
 	return nil
 }
+
+var humanDurationUnits = map[string]time.Duration{
+	"ns":      time.Nanosecond,
+	"nsec":    time.Nanosecond,
+	"us":      time.Microsecond,
+	"usec":    time.Microsecond,
+	"ms":      time.Millisecond,
+	"msec":    time.Millisecond,
+	"s":       time.Second,
+	"sec":     time.Second,
+	"secs":    time.Second,
+	"second":  time.Second,
+	"seconds": time.Second,
+	"m":       time.Minute,
+	"min":     time.Minute,
+	"mins":    time.Minute,
+	"minute":  time.Minute,
+	"minutes": time.Minute,
+	"h":       time.Hour,
+	"hr":      time.Hour,
+	"hrs":     time.Hour,
+	"hour":    time.Hour,
+	"hours":   time.Hour,
+	"d":       24 * time.Hour,
+	"day":     24 * time.Hour,
+	"days":    24 * time.Hour,
+	"w":       7 * 24 * time.Hour,
+	"week":    7 * 24 * time.Hour,
+	"weeks":   7 * 24 * time.Hour,
+	"M":       2630016 * time.Second,
+	"month":   2630016 * time.Second,
+	"months":  2630016 * time.Second,
+	"y":       31557600 * time.Second,
+	"year":    31557600 * time.Second,
+	"years":   31557600 * time.Second,
+}
+
+// parseHumanDuration parses durations in the humantime format used by the node, e.g. "30m", "1day" or "1h 30m"
+func parseHumanDuration(s string) (time.Duration, error) {
+	var result time.Duration
+	rest := strings.TrimSpace(s)
+
+	if rest == "" {
+		return 0, fmt.Errorf("invalid duration: %q", s)
+	}
+
+	for rest != "" {
+		i := 0
+		for i < len(rest) && rest[i] >= '0' && rest[i] <= '9' {
+			i++
+		}
+		if i == 0 {
+			return 0, fmt.Errorf("invalid duration: %q", s)
+		}
+
+		value, err := strconv.ParseInt(rest[:i], 10, 64)
+		if err != nil {
+			return 0, fmt.Errorf("invalid duration: %q", s)
+		}
+		rest = rest[i:]
+
+		j := 0
+		for j < len(rest) && (rest[j] < '0' || rest[j] > '9') && rest[j] != ' ' {
+			j++
+		}
+
+		unit, ok := humanDurationUnits[rest[:j]]
+		if !ok {
+			return 0, fmt.Errorf("invalid duration unit %q in %q", rest[:j], s)
+		}
+
+		result += time.Duration(value) * unit
+		rest = strings.TrimLeft(rest[j:], " ")
+	}
+
+	return result, nil
+}
+
+// formatHumanDuration formats durations like the humantime crate used by the node, the inverse of parseHumanDuration
+func formatHumanDuration(d time.Duration) string {
+	if d <= 0 {
+		return "0s"
+	}
+
+	secs := int64(d / time.Second)
+	nanos := int64(d % time.Second)
+
+	years := secs / 31557600
+	secs %= 31557600
+	months := secs / 2630016
+	secs %= 2630016
+	days := secs / 86400
+	secs %= 86400
+
+	parts := make([]string, 0)
+	plural := func(value int64, unit string) {
+		switch {
+		case value == 1:
+			parts = append(parts, fmt.Sprintf("%d%s", value, unit))
+		case value > 1:
+			parts = append(parts, fmt.Sprintf("%d%ss", value, unit))
+		}
+	}
+	short := func(value int64, unit string) {
+		if value > 0 {
+			parts = append(parts, fmt.Sprintf("%d%s", value, unit))
+		}
+	}
+
+	plural(years, "year")
+	plural(months, "month")
+	plural(days, "day")
+	short(secs/3600, "h")
+	short(secs%3600/60, "m")
+	short(secs%60, "s")
+	short(nanos/1000000, "ms")
+	short(nanos/1000%1000, "us")
+	short(nanos%1000, "ns")
+
+	return strings.Join(parts, " ")
+}
@@ -2,6 +2,8 @@ package types
 
 import (
 	"encoding/hex"
+	"errors"
+	"fmt"
 	"io"
 
 	"github.com/casper-ecosystem/casper-golang-sdk/serialization"
@@ -50,3 +52,18 @@ func (clmap CLMap) Marshal(w io.Writer) (int, error) {
 
 	return w.Write(bytes)
 }
+
+// keyType returns the full type of the keys. The keys are kept encoded, so only the length of a ByteArray key can be
+// derived from them, the nested types of the other compound keys need TypeInfo
+func (clmap CLMap) keyType() (CLTypeInfo, error) {
+	switch clmap.KeyType {
+	case CLTypeByteArray:
+		for k := range clmap.Raw {
+			return ByteArrayCLType(uint32(hex.DecodedLen(len(k)))), nil
+		}
+		return CLTypeInfo{}, errors.New("can't derive the key length of an empty map, TypeInfo is required")
+	case CLTypeOption, CLTypeList, CLTypeResult, CLTypeMap, CLTypeTuple1, CLTypeTuple2, CLTypeTuple3:
+		return CLTypeInfo{}, fmt.Errorf("can't derive the nested types of %s keys, TypeInfo is required", clmap.KeyType.ToString())
+	}
+	return SimpleCLType(clmap.KeyType), nil
+}
@@ -0,0 +1,406 @@
+package types
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// maxCLTypeDepth limits the nesting of decoded types, it matches the limit of the node
+const maxCLTypeDepth = 50
+
+// CLTypeInfo is the complete, recursive description of a CLType.
+// Only the fields matching Type are set, e.g. Inner for Option and List or Key and Value for Map
+type CLTypeInfo struct {
+	Type CLType
+	// Inner is the type of the Option value or the List elements
+	Inner *CLTypeInfo
+	// Size is the length of a ByteArray
+	Size uint32
+	// Ok and Err are the types of the Result variants
+	Ok  *CLTypeInfo
+	Err *CLTypeInfo
+	// Key and Value are the types of the Map entries
+	Key   *CLTypeInfo
+	Value *CLTypeInfo
+	// Tuple holds the types of the Tuple1, Tuple2 or Tuple3 elements
+	Tuple []CLTypeInfo
+}
+
+// SimpleCLType describes a type without nested types, e.g. CLTypeU512 or CLTypeKey
+func SimpleCLType(t CLType) CLTypeInfo {
+	return CLTypeInfo{Type: t}
+}
+
+func OptionCLType(inner CLTypeInfo) CLTypeInfo {
+	return CLTypeInfo{Type: CLTypeOption, Inner: &inner}
+}
+
+func ListCLType(inner CLTypeInfo) CLTypeInfo {
+	return CLTypeInfo{Type: CLTypeList, Inner: &inner}
+}
+
+func ByteArrayCLType(size uint32) CLTypeInfo {
+	return CLTypeInfo{Type: CLTypeByteArray, Size: size}
+}
+
+func ResultCLType(ok, err CLTypeInfo) CLTypeInfo {
+	return CLTypeInfo{Type: CLTypeResult, Ok: &ok, Err: &err}
+}
+
+func MapCLType(key, value CLTypeInfo) CLTypeInfo {
+	return CLTypeInfo{Type: CLTypeMap, Key: &key, Value: &value}
+}
+
+// TupleCLType describes a Tuple1, Tuple2 or Tuple3 depending on the number of elements
+func TupleCLType(elements ...CLTypeInfo) CLTypeInfo {
+	t := CLTypeInfo{Tuple: elements}
+	switch len(elements) {
+	case 1:
+		t.Type = CLTypeTuple1
+	case 2:
+		t.Type = CLTypeTuple2
+	default:
+		t.Type = CLTypeTuple3
+	}
+	return t
+}
+
+// tupleSize returns the number of elements of a tuple type, 0 for other types
+func tupleSize(t CLType) int {
+	switch t {
+	case CLTypeTuple1:
+		return 1
+	case CLTypeTuple2:
+		return 2
+	case CLTypeTuple3:
+		return 3
+	}
+	return 0
+}
+
+// Validate checks that the nested types required by Type are present
+func (t CLTypeInfo) Validate() error {
+	switch t.Type {
+	case CLTypeOption, CLTypeList:
+		if t.Inner == nil {
+			return fmt.Errorf("missing inner type of %s", t.Type.ToString())
+		}
+		return t.Inner.Validate()
+	case CLTypeResult:
+		if t.Ok == nil || t.Err == nil {
+			return errors.New("missing ok or err type of Result")
+		}
+		if err := t.Ok.Validate(); err != nil {
+			return err
+		}
+		return t.Err.Validate()
+	case CLTypeMap:
+		if t.Key == nil || t.Value == nil {
+			return errors.New("missing key or value type of Map")
+		}
+		if err := t.Key.Validate(); err != nil {
+			return err
+		}
+		return t.Value.Validate()
+	case CLTypeTuple1, CLTypeTuple2, CLTypeTuple3:
+		if len(t.Tuple) != tupleSize(t.Type) {
+			return fmt.Errorf("%s needs %d element types, got %d", t.Type.ToString(), tupleSize(t.Type), len(t.Tuple))
+		}
+		for _, element := range t.Tuple {
+			if err := element.Validate(); err != nil {
+				return err
+			}
+		}
+		return nil
+	case CLTypeBool, CLTypeI32, CLTypeI64, CLTypeU8, CLTypeU32, CLTypeU64, CLTypeU128, CLTypeU256, CLTypeU512,
+		CLTypeUnit, CLTypeString, CLTypeKey, CLTypeURef, CLTypeByteArray, CLTypeAny, CLTypePublicKey:
+		return nil
+	}
+
+	return fmt.Errorf("unknown cl type tag %d", t.Type)
+}
+
+// Equal reports whether both types, including all nested types, are the same
+func (t CLTypeInfo) Equal(other CLTypeInfo) bool {
+	if t.Type != other.Type {
+		return false
+	}
+
+	switch t.Type {
+	case CLTypeOption, CLTypeList:
+		return equalCLTypeInfo(t.Inner, other.Inner)
+	case CLTypeByteArray:
+		return t.Size == other.Size
+	case CLTypeResult:
+		return equalCLTypeInfo(t.Ok, other.Ok) && equalCLTypeInfo(t.Err, other.Err)
+	case CLTypeMap:
+		return equalCLTypeInfo(t.Key, other.Key) && equalCLTypeInfo(t.Value, other.Value)
+	case CLTypeTuple1, CLTypeTuple2, CLTypeTuple3:
+		if len(t.Tuple) != len(other.Tuple) {
+			return false
+		}
+		for i := range t.Tuple {
+			if !t.Tuple[i].Equal(other.Tuple[i]) {
+				return false
+			}
+		}
+	}
+
+	return true
+}
+
+func equalCLTypeInfo(a, b *CLTypeInfo) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return a.Equal(*b)
+}
+
+// String returns the type in a readable form, e.g. Map<String, List<Key>>
+func (t CLTypeInfo) String() string {
+	switch t.Type {
+	case CLTypeOption, CLTypeList:
+		return fmt.Sprintf("%s<%s>", t.Type.ToString(), optionalCLTypeString(t.Inner))
+	case CLTypeByteArray:
+		return fmt.Sprintf("ByteArray<%d>", t.Size)
+	case CLTypeResult:
+		return fmt.Sprintf("Result<%s, %s>", optionalCLTypeString(t.Ok), optionalCLTypeString(t.Err))
+	case CLTypeMap:
+		return fmt.Sprintf("Map<%s, %s>", optionalCLTypeString(t.Key), optionalCLTypeString(t.Value))
+	case CLTypeTuple1, CLTypeTuple2, CLTypeTuple3:
+		elements := make([]string, len(t.Tuple))
+		for i, element := range t.Tuple {
+			elements[i] = element.String()
+		}
+		return fmt.Sprintf("%s<%s>", t.Type.ToString(), strings.Join(elements, ", "))
+	}
+
+	return t.Type.ToString()
+}
+
+func optionalCLTypeString(t *CLTypeInfo) string {
+	if t == nil {
+		return "?"
+	}
+	return t.String()
+}
+
+// Marshal writes the bytesrepr encoding of the type, the tag followed by the nested types
+func (t CLTypeInfo) Marshal(w io.Writer) (int, error) {
+	b, err := t.ToBytes()
+	if err != nil {
+		return 0, err
+	}
+
+	return w.Write(b)
+}
+
+func (t CLTypeInfo) ToBytes() ([]byte, error) {
+	if err := t.Validate(); err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	t.writeBytes(&buf)
+	return buf.Bytes(), nil
+}
+
+func (t CLTypeInfo) writeBytes(buf *bytes.Buffer) {
+	buf.WriteByte(byte(t.Type))
+
+	switch t.Type {
+	case CLTypeOption, CLTypeList:
+		t.Inner.writeBytes(buf)
+	case CLTypeByteArray:
+		var size [4]byte
+		binary.LittleEndian.PutUint32(size[:], t.Size)
+		buf.Write(size[:])
+	case CLTypeResult:
+		t.Ok.writeBytes(buf)
+		t.Err.writeBytes(buf)
+	case CLTypeMap:
+		t.Key.writeBytes(buf)
+		t.Value.writeBytes(buf)
+	case CLTypeTuple1, CLTypeTuple2, CLTypeTuple3:
+		for _, element := range t.Tuple {
+			element.writeBytes(buf)
+		}
+	}
+}
+
+// UnmarshalCLTypeInfo decodes a bytesrepr encoded type and returns the number of bytes read
+func UnmarshalCLTypeInfo(src []byte, dest *CLTypeInfo) (int, error) {
+	return unmarshalCLTypeInfo(src, dest, 0)
+}
+
+func unmarshalCLTypeInfo(src []byte, dest *CLTypeInfo, depth int) (int, error) {
+	if depth > maxCLTypeDepth {
+		return 0, errors.New("cl type nested too deeply")
+	}
+	if len(src) == 0 {
+		return 0, errors.New("unexpected end of cl type bytes")
+	}
+
+	*dest = CLTypeInfo{Type: CLType(src[0])}
+	n := 1
+
+	decodeNested := func() (*CLTypeInfo, error) {
+		var nested CLTypeInfo
+		n1, err := unmarshalCLTypeInfo(src[n:], &nested, depth+1)
+		n += n1
+		return &nested, err
+	}
+
+	var err error
+	switch dest.Type {
+	case CLTypeOption, CLTypeList:
+		dest.Inner, err = decodeNested()
+	case CLTypeByteArray:
+		if len(src) < n+4 {
+			return n, errors.New("unexpected end of cl type bytes")
+		}
+		dest.Size = binary.LittleEndian.Uint32(src[n:])
+		n += 4
+	case CLTypeResult:
+		if dest.Ok, err = decodeNested(); err == nil {
+			dest.Err, err = decodeNested()
+		}
+	case CLTypeMap:
+		if dest.Key, err = decodeNested(); err == nil {
+			dest.Value, err = decodeNested()
+		}
+	case CLTypeTuple1, CLTypeTuple2, CLTypeTuple3:
+		dest.Tuple = make([]CLTypeInfo, tupleSize(dest.Type))
+		for i := range dest.Tuple {
+			var element *CLTypeInfo
+			if element, err = decodeNested(); err != nil {
+				break
+			}
+			dest.Tuple[i] = *element
+		}
+	case CLTypeBool, CLTypeI32, CLTypeI64, CLTypeU8, CLTypeU32, CLTypeU64, CLTypeU128, CLTypeU256, CLTypeU512,
+		CLTypeUnit, CLTypeString, CLTypeKey, CLTypeURef, CLTypeAny, CLTypePublicKey:
+	default:
+		return n, fmt.Errorf("unknown cl type tag %d", dest.Type)
+	}
+
+	return n, err
+}
+
+type jsonResultCLType struct {
+	Ok  *CLTypeInfo `json:"ok"`
+	Err *CLTypeInfo `json:"err"`
+}
+
+type jsonMapCLType struct {
+	Key   *CLTypeInfo `json:"key"`
+	Value *CLTypeInfo `json:"value"`
+}
+
+// MarshalJSON encodes the type in the cl_type format of the node, e.g. {"List":{"Option":"U64"}}
+func (t CLTypeInfo) MarshalJSON() ([]byte, error) {
+	if err := t.Validate(); err != nil {
+		return nil, err
+	}
+
+	var nested interface{}
+
+	switch t.Type {
+	case CLTypeOption, CLTypeList:
+		nested = t.Inner
+	case CLTypeByteArray:
+		nested = t.Size
+	case CLTypeResult:
+		nested = jsonResultCLType{Ok: t.Ok, Err: t.Err}
+	case CLTypeMap:
+		nested = jsonMapCLType{Key: t.Key, Value: t.Value}
+	case CLTypeTuple1, CLTypeTuple2, CLTypeTuple3:
+		nested = t.Tuple
+	default:
+		return json.Marshal(t.Type.ToString())
+	}
+
+	return json.Marshal(map[string]interface{}{t.Type.ToString(): nested})
+}
+
+func (t *CLTypeInfo) UnmarshalJSON(data []byte) error {
+	var name string
+	if err := json.Unmarshal(data, &name); err == nil {
+		clType, ok := simpleCLTypes[name]
+		if !ok {
+			return fmt.Errorf("unknown cl type %q", name)
+		}
+		*t = CLTypeInfo{Type: clType}
+		return nil
+	}
+
+	var composite map[string]json.RawMessage
+	if err := json.Unmarshal(data, &composite); err != nil {
+		return fmt.Errorf("invalid cl type: %w", err)
+	}
+	if len(composite) != 1 {
+		return errors.New("invalid cl type: expected exactly one type name")
+	}
+
+	for name, nested := range composite {
+		var result CLTypeInfo
+		var err error
+
+		switch name {
+		case "Option", "List":
+			result.Type = FromString(name)
+			err = json.Unmarshal(nested, &result.Inner)
+		case "ByteArray":
+			result.Type = CLTypeByteArray
+			err = json.Unmarshal(nested, &result.Size)
+		case "Result":
+			var resultTypes jsonResultCLType
+			err = json.Unmarshal(nested, &resultTypes)
+			result = CLTypeInfo{Type: CLTypeResult, Ok: resultTypes.Ok, Err: resultTypes.Err}
+		case "Map":
+			var mapTypes jsonMapCLType
+			err = json.Unmarshal(nested, &mapTypes)
+			result = CLTypeInfo{Type: CLTypeMap, Key: mapTypes.Key, Value: mapTypes.Value}
+		case "Tuple1", "Tuple2", "Tuple3":
+			result.Type = FromString(name)
+			err = json.Unmarshal(nested, &result.Tuple)
+		default:
+			return fmt.Errorf("unknown cl type %q", name)
+		}
+
+		if err != nil {
+			return fmt.Errorf("invalid %s cl type: %w", name, err)
+		}
+		if err := result.Validate(); err != nil {
+			return err
+		}
+
+		*t = result
+	}
+
+	return nil
+}
+
+// simpleCLTypes are the types encoded as a plain string in json
+var simpleCLTypes = map[string]CLType{
+	"Bool":      CLTypeBool,
+	"I32":       CLTypeI32,
+	"I64":       CLTypeI64,
+	"U8":        CLTypeU8,
+	"U32":       CLTypeU32,
+	"U64":       CLTypeU64,
+	"U128":      CLTypeU128,
+	"U256":      CLTypeU256,
+	"U512":      CLTypeU512,
+	"Unit":      CLTypeUnit,
+	"String":    CLTypeString,
+	"Key":       CLTypeKey,
+	"URef":      CLTypeURef,
+	"Any":       CLTypeAny,
+	"PublicKey": CLTypePublicKey,
+}
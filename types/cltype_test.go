@@ -0,0 +1,181 @@
+package types
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"testing"
+
+	"github.com/casper-ecosystem/casper-golang-sdk/serialization"
+	"github.com/stretchr/testify/assert"
+)
+
+var clTypeCases = []struct {
+	Name   string
+	Type   CLTypeInfo
+	Hex    string
+	JSON   string
+	String string
+}{
+	{
+		"U512",
+		SimpleCLType(CLTypeU512),
+		"08",
+		`"U512"`,
+		"U512",
+	},
+	{
+		"List<Option<U64>>",
+		ListCLType(OptionCLType(SimpleCLType(CLTypeU64))),
+		"0e0d05",
+		`{"List":{"Option":"U64"}}`,
+		"List<Option<U64>>",
+	},
+	{
+		"Map<String, List<Key>>",
+		MapCLType(SimpleCLType(CLTypeString), ListCLType(SimpleCLType(CLTypeKey))),
+		"110a0e0b",
+		`{"Map":{"key":"String","value":{"List":"Key"}}}`,
+		"Map<String, List<Key>>",
+	},
+	{
+		"Result<U512, String>",
+		ResultCLType(SimpleCLType(CLTypeU512), SimpleCLType(CLTypeString)),
+		"10080a",
+		`{"Result":{"ok":"U512","err":"String"}}`,
+		"Result<U512, String>",
+	},
+	{
+		"ByteArray<32>",
+		ByteArrayCLType(32),
+		"0f20000000",
+		`{"ByteArray":32}`,
+		"ByteArray<32>",
+	},
+	{
+		"Tuple3<U32, String, Option<PublicKey>>",
+		TupleCLType(SimpleCLType(CLTypeU32), SimpleCLType(CLTypeString), OptionCLType(SimpleCLType(CLTypePublicKey))),
+		"14040a0d16",
+		`{"Tuple3":["U32","String",{"Option":"PublicKey"}]}`,
+		"Tuple3<U32, String, Option<PublicKey>>",
+	},
+}
+
+func TestCLTypeInfo_Encoding(t *testing.T) {
+	for _, c := range clTypeCases {
+		result, err := serialization.Marshal(c.Type)
+		if !assert.NoError(t, err, c.Name) {
+			continue
+		}
+		assert.Equal(t, c.Hex, hex.EncodeToString(result), c.Name)
+
+		var decoded CLTypeInfo
+		n, err := UnmarshalCLTypeInfo(result, &decoded)
+		assert.NoError(t, err, c.Name)
+		assert.Equal(t, len(result), n, c.Name)
+		assert.True(t, c.Type.Equal(decoded), c.Name)
+	}
+}
+
+func TestCLTypeInfo_JSON(t *testing.T) {
+	for _, c := range clTypeCases {
+		result, err := json.Marshal(c.Type)
+		if !assert.NoError(t, err, c.Name) {
+			continue
+		}
+		assert.JSONEq(t, c.JSON, string(result), c.Name)
+
+		var decoded CLTypeInfo
+		err = json.Unmarshal([]byte(c.JSON), &decoded)
+		assert.NoError(t, err, c.Name)
+		assert.True(t, c.Type.Equal(decoded), c.Name)
+	}
+}
+
+func TestCLTypeInfo_String(t *testing.T) {
+	for _, c := range clTypeCases {
+		assert.Equal(t, c.String, c.Type.String())
+	}
+}
+
+func TestCLTypeInfo_Equal(t *testing.T) {
+	assert.False(t, ListCLType(SimpleCLType(CLTypeU64)).Equal(ListCLType(SimpleCLType(CLTypeU32))))
+	assert.False(t, ByteArrayCLType(32).Equal(ByteArrayCLType(33)))
+	assert.False(t, TupleCLType(SimpleCLType(CLTypeU64)).Equal(TupleCLType(SimpleCLType(CLTypeU64), SimpleCLType(CLTypeU64))))
+	assert.True(t, MapCLType(SimpleCLType(CLTypeString), SimpleCLType(CLTypeKey)).Equal(MapCLType(SimpleCLType(CLTypeString), SimpleCLType(CLTypeKey))))
+}
+
+func TestCLTypeInfo_Invalid(t *testing.T) {
+	var decoded CLTypeInfo
+
+	for _, c := range []string{"0e", "0f2000", "ff", "1004"} {
+		src, _ := hex.DecodeString(c)
+		_, err := UnmarshalCLTypeInfo(src, &decoded)
+		assert.Error(t, err, c)
+	}
+
+	for _, c := range []string{`"Foo"`, `{"List":null}`, `{"Map":{"key":"String"}}`, `{"Tuple2":["U8"]}`, `{"List":"U8","Option":"U8"}`} {
+		err := json.Unmarshal([]byte(c), &decoded)
+		assert.Error(t, err, c)
+	}
+
+	_, err := serialization.Marshal(CLTypeInfo{Type: CLTypeOption})
+	assert.Error(t, err)
+}
+
+func TestCLValue_FullType(t *testing.T) {
+	value := CLValue{Type: CLTypeList, List: &[]CLValue{
+		{Type: CLTypeOption, Option: &CLValue{Type: CLTypeU64, U64: createPtrU64(1)}},
+	}}
+
+	clType, err := value.FullType()
+	assert.NoError(t, err)
+	assert.Equal(t, "List<Option<U64>>", clType.String())
+
+	_, err = CLValue{Type: CLTypeOption}.FullType()
+	assert.Error(t, err)
+
+	info := OptionCLType(SimpleCLType(CLTypeString))
+	clType, err = CLValue{Type: CLTypeOption, TypeInfo: &info}.FullType()
+	assert.NoError(t, err)
+	assert.True(t, info.Equal(clType))
+
+	byteArrayKeys := CLValue{Type: CLTypeMap, Map: &CLMap{
+		KeyType:   CLTypeByteArray,
+		ValueType: CLTypeU8,
+		Raw:       map[string]CLValue{"0102": {Type: CLTypeU8, U8: createPtrU8(1)}},
+	}}
+	clType, err = byteArrayKeys.FullType()
+	assert.NoError(t, err)
+	assert.True(t, MapCLType(ByteArrayCLType(2), SimpleCLType(CLTypeU8)).Equal(clType), clType.String())
+
+	// the nested types of an encoded Option key are unknown
+	_, err = CLValue{Type: CLTypeMap, Map: &CLMap{KeyType: CLTypeOption, ValueType: CLTypeU8}}.FullType()
+	assert.Error(t, err)
+}
+
+func TestUnmarshalCLValue_TypeInfo(t *testing.T) {
+	info := ListCLType(OptionCLType(SimpleCLType(CLTypeU64)))
+	src, _ := hex.DecodeString("0200000001070000000000000001ff00000000000000")
+
+	result := CLValue{TypeInfo: &info}
+	_, err := UnmarshalCLValue(src, &result)
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	assert.Equal(t, CLTypeList, result.Type)
+	assert.Len(t, *result.List, 2)
+	assert.Equal(t, uint64(7), *(*result.List)[0].Option.U64)
+	assert.Equal(t, uint64(255), *(*result.List)[1].Option.U64)
+
+	mapInfo := MapCLType(SimpleCLType(CLTypeString), ListCLType(SimpleCLType(CLTypeU32)))
+	src, _ = hex.DecodeString("01000000040000007465737401000000" + "07000000")
+
+	result = CLValue{TypeInfo: &mapInfo}
+	_, err = UnmarshalCLValue(src, &result)
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	assert.Equal(t, uint32(7), *(*result.Map.Raw["test"].List)[0].U32)
+}
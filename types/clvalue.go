@@ -1,6 +1,8 @@
 package types
 
 import (
+	"errors"
+	"fmt"
 	"math/big"
 
 	"github.com/casper-ecosystem/casper-golang-sdk/keypair"
@@ -159,6 +161,84 @@ type CLValue struct {
 	Tuple2    *[2]CLValue
 	Tuple3    *[3]CLValue
 	PublicKey *keypair.PublicKey
+	// TypeInfo is the full type of the value, it is needed when the nested types can't be derived from the value itself,
+	// e.g. for None options, empty lists and maps or results
+	TypeInfo *CLTypeInfo
+}
+
+// FullType returns TypeInfo if set, otherwise the type is derived from the value and its nested values
+func (v CLValue) FullType() (CLTypeInfo, error) {
+	if v.TypeInfo != nil {
+		return *v.TypeInfo, nil
+	}
+
+	switch v.Type {
+	case CLTypeOption:
+		if v.Option == nil {
+			return CLTypeInfo{}, errors.New("can't derive the inner type of a None option, TypeInfo is required")
+		}
+		inner, err := v.Option.FullType()
+		if err != nil {
+			return CLTypeInfo{}, err
+		}
+		return OptionCLType(inner), nil
+	case CLTypeList:
+		if v.List == nil || len(*v.List) == 0 {
+			return CLTypeInfo{}, errors.New("can't derive the element type of an empty list, TypeInfo is required")
+		}
+		inner, err := (*v.List)[0].FullType()
+		if err != nil {
+			return CLTypeInfo{}, err
+		}
+		return ListCLType(inner), nil
+	case CLTypeByteArray:
+		if v.ByteArray == nil {
+			return CLTypeInfo{}, errors.New("missing byte array value")
+		}
+		return ByteArrayCLType(uint32(len(*v.ByteArray))), nil
+	case CLTypeResult:
+		return CLTypeInfo{}, errors.New("can't derive both variant types of a result, TypeInfo is required")
+	case CLTypeMap:
+		if v.Map == nil {
+			return CLTypeInfo{}, errors.New("missing map value")
+		}
+		key, err := v.Map.keyType()
+		if err != nil {
+			return CLTypeInfo{}, err
+		}
+		value := SimpleCLType(v.Map.ValueType)
+		for _, entry := range v.Map.Raw {
+			if value, err = entry.FullType(); err != nil {
+				return CLTypeInfo{}, err
+			}
+			break
+		}
+		return MapCLType(key, value), nil
+	case CLTypeTuple1, CLTypeTuple2, CLTypeTuple3:
+		var elements []CLValue
+		switch {
+		case v.Tuple1 != nil:
+			elements = v.Tuple1[:]
+		case v.Tuple2 != nil:
+			elements = v.Tuple2[:]
+		case v.Tuple3 != nil:
+			elements = v.Tuple3[:]
+		}
+		if len(elements) != tupleSize(v.Type) {
+			return CLTypeInfo{}, errors.New("missing tuple value")
+		}
+
+		types := make([]CLTypeInfo, len(elements))
+		for i, element := range elements {
+			var err error
+			if types[i], err = element.FullType(); err != nil {
+				return CLTypeInfo{}, err
+			}
+		}
+		return TupleCLType(types...), nil
+	}
+
+	return SimpleCLType(v.Type), nil
 }
 
 func (v CLValue) SwitchFieldName() string {
@@ -212,8 +292,212 @@ func (v CLValue) ArmForSwitch(sw byte) (string, bool) {
 	case CLTypeAny:
 		return "-", false
 	case CLTypePublicKey:
-		return "PublicKey", false
+		return "PublicKey", true
 	}
 
 	return "-", false
 }
+
+// ErrCLTypeMismatch is returned by the accessors of CLValue when the value has another type
+var ErrCLTypeMismatch = errors.New("cl type mismatch")
+
+func (v CLValue) typeMismatch(expected string) error {
+	actual := v.Type.ToString()
+	if clType, err := v.FullType(); err == nil {
+		actual = clType.String()
+	}
+	return fmt.Errorf("%w: expected %s, got %s", ErrCLTypeMismatch, expected, actual)
+}
+
+func (v CLValue) missingValue() error {
+	return fmt.Errorf("missing %s value", v.Type.ToString())
+}
+
+// AsBool returns the value of a Bool
+func (v CLValue) AsBool() (bool, error) {
+	if v.Type != CLTypeBool {
+		return false, v.typeMismatch("Bool")
+	}
+	if v.Bool == nil {
+		return false, v.missingValue()
+	}
+	return *v.Bool, nil
+}
+
+// AsBigInt returns the value of any integer type, e.g. the U256 balance of a token
+func (v CLValue) AsBigInt() (*big.Int, error) {
+	var result *big.Int
+
+	switch v.Type {
+	case CLTypeI32:
+		if v.I32 != nil {
+			result = big.NewInt(int64(*v.I32))
+		}
+	case CLTypeI64:
+		if v.I64 != nil {
+			result = big.NewInt(*v.I64)
+		}
+	case CLTypeU8:
+		if v.U8 != nil {
+			result = new(big.Int).SetUint64(uint64(*v.U8))
+		}
+	case CLTypeU32:
+		if v.U32 != nil {
+			result = new(big.Int).SetUint64(uint64(*v.U32))
+		}
+	case CLTypeU64:
+		if v.U64 != nil {
+			result = new(big.Int).SetUint64(*v.U64)
+		}
+	case CLTypeU128:
+		result = copyBigInt(v.U128)
+	case CLTypeU256:
+		result = copyBigInt(v.U256)
+	case CLTypeU512:
+		result = copyBigInt(v.U512)
+	default:
+		return nil, v.typeMismatch("an integer")
+	}
+
+	if result == nil {
+		return nil, v.missingValue()
+	}
+	return result, nil
+}
+
+func copyBigInt(i *big.Int) *big.Int {
+	if i == nil {
+		return nil
+	}
+	return new(big.Int).Set(i)
+}
+
+// AsUint64 returns the value of the unsigned integer types which fit into an uint64
+func (v CLValue) AsUint64() (uint64, error) {
+	switch v.Type {
+	case CLTypeU8, CLTypeU32, CLTypeU64:
+		i, err := v.AsBigInt()
+		if err != nil {
+			return 0, err
+		}
+		return i.Uint64(), nil
+	}
+	return 0, v.typeMismatch("U8, U32 or U64")
+}
+
+// AsString returns the value of a String
+func (v CLValue) AsString() (string, error) {
+	if v.Type != CLTypeString {
+		return "", v.typeMismatch("String")
+	}
+	if v.String == nil {
+		return "", v.missingValue()
+	}
+	return *v.String, nil
+}
+
+// AsBytes returns the value of a ByteArray
+func (v CLValue) AsBytes() ([]byte, error) {
+	if v.Type != CLTypeByteArray {
+		return nil, v.typeMismatch("ByteArray")
+	}
+	if v.ByteArray == nil {
+		return nil, v.missingValue()
+	}
+	return *v.ByteArray, nil
+}
+
+// AsKey returns the value of a Key
+func (v CLValue) AsKey() (Key, error) {
+	if v.Type != CLTypeKey {
+		return Key{}, v.typeMismatch("Key")
+	}
+	if v.Key == nil {
+		return Key{}, v.missingValue()
+	}
+	return *v.Key, nil
+}
+
+// AsURef returns the value of a URef
+func (v CLValue) AsURef() (URef, error) {
+	if v.Type != CLTypeURef {
+		return URef{}, v.typeMismatch("URef")
+	}
+	if v.URef == nil {
+		return URef{}, v.missingValue()
+	}
+	return *v.URef, nil
+}
+
+// AsPublicKey returns the value of a PublicKey
+func (v CLValue) AsPublicKey() (keypair.PublicKey, error) {
+	if v.Type != CLTypePublicKey {
+		return keypair.PublicKey{}, v.typeMismatch("PublicKey")
+	}
+	if v.PublicKey == nil {
+		return keypair.PublicKey{}, v.missingValue()
+	}
+	return *v.PublicKey, nil
+}
+
+// AsOption returns the value of a Some option or nil for None
+func (v CLValue) AsOption() (*CLValue, error) {
+	if v.Type != CLTypeOption {
+		return nil, v.typeMismatch("Option")
+	}
+	return v.Option, nil
+}
+
+// AsList returns the elements of a List
+func (v CLValue) AsList() ([]CLValue, error) {
+	if v.Type != CLTypeList {
+		return nil, v.typeMismatch("List")
+	}
+	if v.List == nil {
+		return []CLValue{}, nil
+	}
+	return *v.List, nil
+}
+
+// AsMap returns the entries of a Map. String keys are used as they are, other keys are hex encoded
+func (v CLValue) AsMap() (map[string]CLValue, error) {
+	if v.Type != CLTypeMap {
+		return nil, v.typeMismatch("Map")
+	}
+	if v.Map == nil || v.Map.Raw == nil {
+		return map[string]CLValue{}, nil
+	}
+	return v.Map.Raw, nil
+}
+
+// AsTuple returns the elements of a Tuple1, Tuple2 or Tuple3
+func (v CLValue) AsTuple() ([]CLValue, error) {
+	switch {
+	case v.Type == CLTypeTuple1 && v.Tuple1 != nil:
+		return v.Tuple1[:], nil
+	case v.Type == CLTypeTuple2 && v.Tuple2 != nil:
+		return v.Tuple2[:], nil
+	case v.Type == CLTypeTuple3 && v.Tuple3 != nil:
+		return v.Tuple3[:], nil
+	case tupleSize(v.Type) > 0:
+		return nil, v.missingValue()
+	}
+	return nil, v.typeMismatch("a tuple")
+}
+
+// AsResult returns the value of an Ok result, or the error value with isOk set to false
+func (v CLValue) AsResult() (value CLValue, isOk bool, err error) {
+	if v.Type != CLTypeResult {
+		return CLValue{}, false, v.typeMismatch("Result")
+	}
+	if v.Result == nil {
+		return CLValue{}, false, v.missingValue()
+	}
+	if v.Result.IsSuccess && v.Result.Success != nil {
+		return *v.Result.Success, true, nil
+	}
+	if !v.Result.IsSuccess && v.Result.Error != nil {
+		return *v.Result.Error, false, nil
+	}
+	return CLValue{}, false, v.missingValue()
+}
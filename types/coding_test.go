@@ -311,6 +311,10 @@ func TestDecoding(t *testing.T) {
 				ValueType: c.Value.Map.ValueType,
 			}
 			break
+		case CLTypeByteArray:
+			placeholder := make(FixedByteArray, len(*c.Value.ByteArray))
+			result.ByteArray = &placeholder
+			break
 		case CLTypeTuple1:
 			result.Tuple1 = &[1]CLValue{{Type: CLTypeU32}}
 			break
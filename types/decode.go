@@ -1,295 +1,459 @@
 package types
 
 import (
-	"bytes"
+	"encoding/binary"
 	"encoding/hex"
 	"errors"
-	"reflect"
+	"fmt"
+	"math/big"
+	"unicode/utf8"
 
-	"github.com/casper-ecosystem/casper-golang-sdk/serialization"
+	"github.com/casper-ecosystem/casper-golang-sdk/keypair"
 )
 
+var errNotEnoughBytes = errors.New("inproper source, not enough bytes")
+
+// DecodeCLValue decodes the bytesrepr encoded value of type t, e.g. the bytes and cl_type of a JsonCLValue.
+// All bytes of src have to belong to the value, the TypeInfo of the returned value is set to t
+func DecodeCLValue(src []byte, t CLTypeInfo) (CLValue, error) {
+	if err := t.Validate(); err != nil {
+		return CLValue{}, err
+	}
+
+	value, n, err := decodeCLValue(src, t, 0)
+	if err != nil {
+		return CLValue{}, fmt.Errorf("failed to decode %s: %w", t, err)
+	}
+
+	if n != len(src) {
+		return CLValue{}, fmt.Errorf("failed to decode %s: %d trailing bytes", t, len(src)-n)
+	}
+
+	value.TypeInfo = &t
+	return value, nil
+}
+
+// UnmarshalCLValue decodes the value at the start of src into dest and returns the number of bytes read.
+// The type is taken from dest.TypeInfo if set, otherwise from dest.Type and the placeholders for the nested types,
+// e.g. the first element of a list, the Option value or the Map key and value types. A ByteArray placeholder
+// has to have the declared length, as the length can't be read from src
 func UnmarshalCLValue(src []byte, dest *CLValue) (int, error) {
-	r := bytes.NewReader(src)
-	dec := serialization.NewDecoder(r)
+	t, err := placeholderType(*dest)
+	if err != nil {
+		return 0, err
+	}
+
+	if err := t.Validate(); err != nil {
+		return 0, err
+	}
+
+	value, n, err := decodeCLValue(src, t, 0)
+	if err != nil {
+		return n, err
+	}
+
+	value.TypeInfo = dest.TypeInfo
+	*dest = value
+	return n, nil
+}
+
+// placeholderType returns the type described by dest.TypeInfo or by the placeholders in dest.
+// Missing placeholders of Option, List and Result values are treated as Any, which can only be decoded if absent
+func placeholderType(dest CLValue) (CLTypeInfo, error) {
+	if dest.TypeInfo != nil {
+		return *dest.TypeInfo, nil
+	}
+
+	unknown := SimpleCLType(CLTypeAny)
+
 	switch dest.Type {
+	case CLTypeOption:
+		if dest.Option == nil {
+			return OptionCLType(unknown), nil
+		}
+		inner, err := placeholderType(*dest.Option)
+		return OptionCLType(inner), err
+	case CLTypeList:
+		if dest.List == nil || len(*dest.List) == 0 {
+			return ListCLType(unknown), nil
+		}
+		inner, err := placeholderType((*dest.List)[0])
+		return ListCLType(inner), err
+	case CLTypeByteArray:
+		// the length is part of the type, a placeholder of the declared length or TypeInfo is needed to know it
+		if dest.ByteArray == nil {
+			return CLTypeInfo{}, errors.New("inproper destination, missing byte array length")
+		}
+		return ByteArrayCLType(uint32(len(*dest.ByteArray))), nil
+	case CLTypeResult:
+		// the error type defaults to String, which most contracts use
+		ok, resultErr := unknown, SimpleCLType(CLTypeString)
+		var err error
+		if dest.Result != nil && dest.Result.Success != nil {
+			if ok, err = placeholderType(*dest.Result.Success); err != nil {
+				return CLTypeInfo{}, err
+			}
+		}
+		if dest.Result != nil && dest.Result.Error != nil {
+			if resultErr, err = placeholderType(*dest.Result.Error); err != nil {
+				return CLTypeInfo{}, err
+			}
+		}
+		return ResultCLType(ok, resultErr), nil
+	case CLTypeMap:
+		if dest.Map == nil {
+			return CLTypeInfo{}, errors.New("inproper destination, missing map inner types")
+		}
+		return MapCLType(SimpleCLType(dest.Map.KeyType), SimpleCLType(dest.Map.ValueType)), nil
+	case CLTypeTuple1, CLTypeTuple2, CLTypeTuple3:
+		var elements []CLValue
+		switch {
+		case dest.Tuple1 != nil:
+			elements = dest.Tuple1[:]
+		case dest.Tuple2 != nil:
+			elements = dest.Tuple2[:]
+		case dest.Tuple3 != nil:
+			elements = dest.Tuple3[:]
+		}
+		if len(elements) != tupleSize(dest.Type) {
+			return CLTypeInfo{}, errors.New("inproper destination, missing tuple inner type")
+		}
+
+		types := make([]CLTypeInfo, len(elements))
+		for i, element := range elements {
+			var err error
+			if types[i], err = placeholderType(element); err != nil {
+				return CLTypeInfo{}, err
+			}
+		}
+		return TupleCLType(types...), nil
+	}
+
+	return SimpleCLType(dest.Type), nil
+}
+
+func decodeCLValue(src []byte, t CLTypeInfo, depth int) (CLValue, int, error) {
+	if depth > maxCLTypeDepth {
+		return CLValue{}, 0, errors.New("cl value nested too deeply")
+	}
+
+	value := CLValue{Type: t.Type}
+
+	switch t.Type {
 	case CLTypeBool:
-		//var temp bool
-		temp, n, err := dec.DecodeBool()
-		//err = serialization.Unmarshal(src, &temp)
-		if err != nil {
-			return 0, err
+		if len(src) < 1 {
+			return CLValue{}, 0, errNotEnoughBytes
 		}
-		dest.Bool = &temp
-		return n, nil
+		if src[0] > 1 {
+			return CLValue{}, 0, errors.New("bool not 0 or 1")
+		}
+		temp := src[0] == 1
+		value.Bool = &temp
+		return value, 1, nil
 	case CLTypeI32:
-		temp, n, err := dec.DecodeInt32()
-		if err != nil {
-			return 0, err
+		if len(src) < 4 {
+			return CLValue{}, 0, errNotEnoughBytes
 		}
-		dest.I32 = &temp
-		return n, nil
+		temp := int32(binary.LittleEndian.Uint32(src))
+		value.I32 = &temp
+		return value, 4, nil
 	case CLTypeI64:
-		temp, n, err := dec.DecodeInt64()
-		if err != nil {
-			return 0, err
+		if len(src) < 8 {
+			return CLValue{}, 0, errNotEnoughBytes
 		}
-		dest.I64 = &temp
-		return n, nil
+		temp := int64(binary.LittleEndian.Uint64(src))
+		value.I64 = &temp
+		return value, 8, nil
 	case CLTypeU8:
-		temp, n, err := dec.DecodeByte()
-		if err != nil {
-			return 0, err
+		if len(src) < 1 {
+			return CLValue{}, 0, errNotEnoughBytes
 		}
-		dest.U8 = &temp
-		return n, nil
+		temp := src[0]
+		value.U8 = &temp
+		return value, 1, nil
 	case CLTypeU32:
-		temp, n, err := dec.DecodeUInt32()
-		if err != nil {
-			return 0, err
+		if len(src) < 4 {
+			return CLValue{}, 0, errNotEnoughBytes
 		}
-		dest.U32 = &temp
-		return n, nil
+		temp := binary.LittleEndian.Uint32(src)
+		value.U32 = &temp
+		return value, 4, nil
 	case CLTypeU64:
-		temp, n, err := dec.DecodeUInt64()
-		if err != nil {
-			return 0, err
+		if len(src) < 8 {
+			return CLValue{}, 0, errNotEnoughBytes
 		}
-		dest.U64 = &temp
-		return n, nil
+		temp := binary.LittleEndian.Uint64(src)
+		value.U64 = &temp
+		return value, 8, nil
 	case CLTypeU128:
-		temp := reflect.New(reflect.TypeOf(serialization.U128{})).Interface()
-		n, err := dec.DecodeU128(reflect.ValueOf(temp).Elem())
-		if err != nil {
-			return 0, err
-		}
-		dest.U128 = &temp.(*serialization.U128).Int
-		return n, nil
+		temp, n, err := decodeBigInt(src, 16)
+		value.U128 = temp
+		return value, n, err
 	case CLTypeU256:
-		temp := reflect.New(reflect.TypeOf(serialization.U256{})).Interface()
-		n, err := dec.DecodeU256(reflect.ValueOf(temp).Elem())
-		if err != nil {
-			return 0, err
-		}
-		dest.U256 = &temp.(*serialization.U256).Int
-		return n, nil
+		temp, n, err := decodeBigInt(src, 32)
+		value.U256 = temp
+		return value, n, err
 	case CLTypeU512:
-		temp := reflect.New(reflect.TypeOf(serialization.U512{})).Interface()
-		n, err := dec.DecodeU512(reflect.ValueOf(temp).Elem())
-		if err != nil {
-			return 0, err
-		}
-		dest.U512 = &temp.(*serialization.U512).Int
-		return n, nil
+		temp, n, err := decodeBigInt(src, 64)
+		value.U512 = temp
+		return value, n, err
 	case CLTypeUnit:
-		return 0, nil
+		return value, 0, nil
 	case CLTypeString:
-		temp, n, err := dec.DecodeString()
-		if err != nil {
-			return 0, err
-		}
-		dest.String = &temp
-		return n, nil
+		temp, n, err := decodeString(src)
+		value.String = &temp
+		return value, n, err
 	case CLTypeKey:
-		if dest.Key == nil {
-			return 0, errors.New("inproper destination, mission key clvalue type")
-		}
-
-		return dest.Key.Unmarshal(src[1:])
+		value.Key = &Key{}
+		n, err := value.Key.Unmarshal(src)
+		return value, n, err
 	case CLTypeURef:
-		dest.URef = &URef{}
-		return dest.URef.Unmarshal(src)
+		value.URef = &URef{}
+		n, err := value.URef.Unmarshal(src)
+		return value, n, err
+	case CLTypePublicKey:
+		temp, n, err := decodePublicKey(src)
+		value.PublicKey = temp
+		return value, n, err
 	case CLTypeOption:
-		var temp CLValue
-		var n int
-
-		if src[0] == byte(0) {
-			dest.Option = nil
-			return 0, nil
-		} else if src[0] == byte(1) {
-			if dest.Option == nil {
-				return 0, errors.New("inproper destination, mission option clvalue type")
-			}
-			temp.Type = dest.Option.Type
-			n1, err := UnmarshalCLValue(src[1:], &temp)
-			if err != nil {
-				return 0, err
-			}
-			n += n1
-		} else {
-			return 0, errors.New("inproper bytes or dest type")
+		if len(src) < 1 {
+			return CLValue{}, 0, errNotEnoughBytes
 		}
-
-		dest.Option = &temp
-		return n, nil
+		switch src[0] {
+		case 0:
+			return value, 1, nil
+		case 1:
+			inner, n, err := decodeCLValue(src[1:], *t.Inner, depth+1)
+			value.Option = &inner
+			return value, n + 1, err
+		}
+		return CLValue{}, 0, fmt.Errorf("invalid option tag %d", src[0])
 	case CLTypeList:
-		size, n, err := dec.DecodeUInt32()
-		if err != nil {
-			return 0, err
+		if len(src) < 4 {
+			return CLValue{}, 0, errNotEnoughBytes
 		}
+		size := binary.LittleEndian.Uint32(src)
+		n := 4
 
-		if size == 0 {
-			list := make([]CLValue, 0)
-			dest.List = &list
-			return n, nil
-		} else {
-			if dest.List == nil || len(*dest.List) == 0 {
-				return n, errors.New("inproper destination, missing first element of list with inner type")
-			}
-
-			list := make([]CLValue, 0)
-			for i := uint32(0); i < size; i++ {
-				var temp CLValue
-				temp.Type = (*dest.List)[0].Type
-				n1, err := UnmarshalCLValue(src[n:], &temp)
-				if err != nil {
-					return n, err
-				}
-				n += n1
-				list = append(list, temp)
-			}
-
-			dest.List = &list
+		if err := checkLength(size, minEncodedSize(*t.Inner), len(src)-n); err != nil {
+			return CLValue{}, n, err
 		}
 
-		return n, nil
-	case CLTypeByteArray:
-		var temp FixedByteArray
-		temp = src
-		dest.ByteArray = &temp
-		return len(src), nil
-	case CLTypeResult:
-		var temp CLValueResult
-		var n int
-		if src[0] == byte(0) {
-			temp.IsSuccess = false
-			resultError := CLValue{
-				Type: CLTypeString,
-			}
-			n1, err := UnmarshalCLValue(src[1:], &resultError)
-			if err != nil {
-				return n, err
-			}
+		list := make([]CLValue, 0, size)
+		for i := uint32(0); i < size; i++ {
+			element, n1, err := decodeCLValue(src[n:], *t.Inner, depth+1)
 			n += n1
-			temp.Error = &resultError
-		} else if src[0] == byte(1) {
-			temp.IsSuccess = true
-			if dest.Result == nil || dest.Result.Success == nil {
-				return n, errors.New("inproper destination, missing result success inner type")
-			}
-			result := CLValue{Type: dest.Result.Success.Type}
-			n1, err := UnmarshalCLValue(src[1:], &result)
 			if err != nil {
-				return n, err
+				return CLValue{}, n, fmt.Errorf("list element %d: %w", i, err)
 			}
-			n += n1
-			temp.Success = &result
-		} else {
-			return n, errors.New("inproper bytes or dest type")
+			list = append(list, element)
+		}
+		value.List = &list
+		return value, n, nil
+	case CLTypeByteArray:
+		if uint64(len(src)) < uint64(t.Size) {
+			return CLValue{}, 0, errNotEnoughBytes
+		}
+		temp := FixedByteArray(append(make([]byte, 0, t.Size), src[:t.Size]...))
+		value.ByteArray = &temp
+		return value, int(t.Size), nil
+	case CLTypeResult:
+		if len(src) < 1 {
+			return CLValue{}, 0, errNotEnoughBytes
 		}
 
-		dest.Result = &temp
-		return n, nil
-	case CLTypeMap:
-		if dest.Map == nil {
-			return 0, errors.New("inproper destination, missing map inner types")
+		var variant CLTypeInfo
+		switch src[0] {
+		case 0:
+			variant = *t.Err
+		case 1:
+			variant = *t.Ok
+		default:
+			return CLValue{}, 0, fmt.Errorf("invalid result tag %d", src[0])
 		}
 
-		size, n, err := dec.DecodeUInt32()
+		inner, n, err := decodeCLValue(src[1:], variant, depth+1)
 		if err != nil {
-			return n, err
+			return CLValue{}, n + 1, err
+		}
+
+		if src[0] == 1 {
+			value.Result = &CLValueResult{IsSuccess: true, Success: &inner}
+		} else {
+			value.Result = &CLValueResult{IsSuccess: false, Error: &inner}
 		}
+		return value, n + 1, nil
+	case CLTypeMap:
+		if len(src) < 4 {
+			return CLValue{}, 0, errNotEnoughBytes
+		}
+		size := binary.LittleEndian.Uint32(src)
+		n := 4
 
-		raw := make(map[string]CLValue)
+		if err := checkLength(size, minEncodedSize(*t.Key)+minEncodedSize(*t.Value), len(src)-n); err != nil {
+			return CLValue{}, n, err
+		}
 
+		raw := make(map[string]CLValue, size)
 		for i := uint32(0); i < size; i++ {
-			var key CLValue
-			key.Type = dest.Map.KeyType
-			n1, err := UnmarshalCLValue(src[n:], &key)
+			key, n1, err := decodeCLValue(src[n:], *t.Key, depth+1)
 			if err != nil {
-				return n, err
+				return CLValue{}, n, fmt.Errorf("map key %d: %w", i, err)
 			}
+
+			var rawKey string
+			if t.Key.Type == CLTypeString {
+				rawKey = *key.String
+			} else {
+				rawKey = hex.EncodeToString(src[n : n+n1])
+			}
+			n += n1
+
+			entry, n1, err := decodeCLValue(src[n:], *t.Value, depth+1)
 			n += n1
-			var value CLValue
-			value.Type = dest.Map.ValueType
-			n1, err = UnmarshalCLValue(src[n:], &value)
 			if err != nil {
-				return n, err
+				return CLValue{}, n, fmt.Errorf("map value %d: %w", i, err)
 			}
-			n += n1
 
-			if key.Type != CLTypeString {
-				marshaledKey, err := serialization.Marshal(key)
-				if err != nil {
-					return n, err
-				}
+			raw[rawKey] = entry
+		}
 
-				raw[hex.EncodeToString(marshaledKey)] = value
-			} else {
-				raw[*key.String] = value
+		value.Map = &CLMap{KeyType: t.Key.Type, ValueType: t.Value.Type, Raw: raw}
+		return value, n, nil
+	case CLTypeTuple1, CLTypeTuple2, CLTypeTuple3:
+		elements := make([]CLValue, len(t.Tuple))
+		n := 0
+		for i, elementType := range t.Tuple {
+			element, n1, err := decodeCLValue(src[n:], elementType, depth+1)
+			n += n1
+			if err != nil {
+				return CLValue{}, n, fmt.Errorf("tuple element %d: %w", i, err)
 			}
+			elements[i] = element
 		}
 
-		dest.Map.Raw = raw
-
-		return n, nil
-	case CLTypeTuple1:
-		if dest.Tuple1 == nil {
-			return 0, errors.New("inproper destination, missing tuple inner type")
-		}
-		temp := CLValue{Type: dest.Tuple1[0].Type}
-		n, err := UnmarshalCLValue(src, &temp)
-		if err != nil {
-			return n, err
-		}
-		dest.Tuple1[0] = temp
-		return n, nil
-	case CLTypeTuple2:
-		if dest.Tuple2 == nil {
-			return 0, errors.New("inproper destination, missing tuple inner type")
-		}
-		temp := CLValue{Type: dest.Tuple2[0].Type}
-		n, err := UnmarshalCLValue(src, &temp)
-		if err != nil {
-			return n, err
-		}
-		dest.Tuple2[0] = temp
-		temp = CLValue{Type: dest.Tuple2[1].Type}
-		n1, err := UnmarshalCLValue(src[n:], &temp)
-		if err != nil {
-			return n, err
-		}
-		n += n1
-		dest.Tuple2[1] = temp
-		return n, nil
-	case CLTypeTuple3:
-		if dest.Tuple3 == nil {
-			return 0, errors.New("inproper destination, missing tuple inner type")
+		switch t.Type {
+		case CLTypeTuple1:
+			value.Tuple1 = &[1]CLValue{elements[0]}
+		case CLTypeTuple2:
+			value.Tuple2 = &[2]CLValue{elements[0], elements[1]}
+		case CLTypeTuple3:
+			value.Tuple3 = &[3]CLValue{elements[0], elements[1], elements[2]}
 		}
-		temp := CLValue{Type: dest.Tuple3[0].Type}
-		n, err := UnmarshalCLValue(src, &temp)
-		if err != nil {
-			return n, err
-		}
-		dest.Tuple3[0] = temp
+		return value, n, nil
+	case CLTypeAny:
+		return CLValue{}, 0, errors.New("values of type Any can't be decoded")
+	}
 
-		temp = CLValue{Type: dest.Tuple3[1].Type}
-		n1, err := UnmarshalCLValue(src[n:], &temp)
-		if err != nil {
-			return n, err
+	return CLValue{}, 0, errors.New("Invalid CLtype provided")
+}
+
+// maxZeroSizedElements limits the length of lists and maps of elements like Unit, which take no bytes,
+// so a length prefix alone can't make the decoder allocate unbounded memory
+const maxZeroSizedElements = 256
+
+// checkLength is done before allocating, the elements of a list or map have to fit into the remaining bytes
+func checkLength(count uint32, minSize uint64, remaining int) error {
+	if minSize == 0 {
+		if count > maxZeroSizedElements {
+			return fmt.Errorf("%d zero sized elements exceed the limit of %d", count, maxZeroSizedElements)
 		}
-		n += n1
-		dest.Tuple3[1] = temp
+		return nil
+	}
 
-		temp = CLValue{Type: dest.Tuple3[2].Type}
-		n1, err = UnmarshalCLValue(src[n:], &temp)
-		if err != nil {
-			return n, err
+	if uint64(count) > uint64(remaining)/minSize {
+		return errNotEnoughBytes
+	}
+	return nil
+}
+
+// minEncodedSize returns the least number of bytes a value of the type is encoded to
+func minEncodedSize(t CLTypeInfo) uint64 {
+	switch t.Type {
+	case CLTypeBool, CLTypeU8, CLTypeU128, CLTypeU256, CLTypeU512, CLTypeOption, CLTypeResult:
+		return 1
+	case CLTypeI32, CLTypeU32, CLTypeString, CLTypeList, CLTypeMap:
+		return 4
+	case CLTypeI64, CLTypeU64:
+		return 8
+	case CLTypeKey:
+		// the EraId key is the shortest, a tag followed by a u64
+		return 9
+	case CLTypeURef, CLTypePublicKey:
+		return 33
+	case CLTypeByteArray:
+		return uint64(t.Size)
+	case CLTypeTuple1, CLTypeTuple2, CLTypeTuple3:
+		var size uint64
+		for _, element := range t.Tuple {
+			size += minEncodedSize(element)
 		}
-		n += n1
-		dest.Tuple3[2] = temp
-		return n, nil
+		return size
+	}
+	return 0
+}
+
+// decodeBigInt decodes the length prefixed little endian U128, U256 or U512 numbers
+func decodeBigInt(src []byte, maxLength int) (*big.Int, int, error) {
+	if len(src) < 1 {
+		return nil, 0, errNotEnoughBytes
+	}
+
+	length := int(src[0])
+	if length > maxLength {
+		return nil, 0, fmt.Errorf("number length %d exceeds %d bytes", length, maxLength)
+	}
+	if len(src) < 1+length {
+		return nil, 0, errNotEnoughBytes
+	}
+
+	bigEndian := make([]byte, length)
+	for i := 0; i < length; i++ {
+		bigEndian[length-i-1] = src[1+i]
+	}
+
+	return new(big.Int).SetBytes(bigEndian), 1 + length, nil
+}
+
+func decodeString(src []byte) (string, int, error) {
+	if len(src) < 4 {
+		return "", 0, errNotEnoughBytes
+	}
+
+	length := binary.LittleEndian.Uint32(src)
+	if uint64(length) > uint64(len(src)-4) {
+		return "", 0, errNotEnoughBytes
+	}
+
+	str := src[4 : 4+length]
+	if !utf8.Valid(str) {
+		return "", 0, errors.New("string is not valid utf-8")
+	}
+
+	return string(str), 4 + int(length), nil
+}
+
+func decodePublicKey(src []byte) (*keypair.PublicKey, int, error) {
+	if len(src) < 1 {
+		return nil, 0, errNotEnoughBytes
+	}
+
+	var size int
+	switch keypair.KeyTag(src[0]) {
+	case keypair.KeyTagEd25519:
+		size = 32
+	case keypair.KeyTagSecp256k1:
+		size = 33
+	default:
+		return nil, 0, fmt.Errorf("invalid public key tag %d", src[0])
+	}
+
+	if len(src) < 1+size {
+		return nil, 0, errNotEnoughBytes
 	}
 
-	return 0, errors.New("Invalid CLtype provided")
+	return &keypair.PublicKey{
+		Tag:        keypair.KeyTag(src[0]),
+		PubKeyData: append(make([]byte, 0, size), src[1:1+size]...),
+	}, 1 + size, nil
 }
@@ -0,0 +1,278 @@
+package types
+
+import (
+	"encoding/hex"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/casper-ecosystem/casper-golang-sdk/keypair"
+	"github.com/casper-ecosystem/casper-golang-sdk/serialization"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDecodeCLValue(t *testing.T) {
+	resultType := ResultCLType(SimpleCLType(CLTypeU64), SimpleCLType(CLTypeString))
+
+	for _, c := range cases {
+		clType, err := c.Value.FullType()
+		if c.Value.Type == CLTypeResult {
+			clType, err = resultType, nil
+		}
+		if c.Value.Type == CLTypeList && len(*c.Value.List) == 0 {
+			clType, err = ListCLType(SimpleCLType(CLTypeU32)), nil
+		}
+		if c.Value.Type == CLTypeOption && c.Value.Option == nil {
+			clType, err = OptionCLType(SimpleCLType(CLTypeU64)), nil
+		}
+		if !assert.NoError(t, err, c.Name) {
+			continue
+		}
+
+		buf, _ := hex.DecodeString(c.HexEncodedValue)
+		result, err := DecodeCLValue(buf, clType)
+		if !assert.NoError(t, err, c.Name) {
+			continue
+		}
+
+		assert.True(t, clType.Equal(*result.TypeInfo), c.Name)
+		result.TypeInfo = nil
+		assert.Equal(t, c.Value, result, c.Name)
+	}
+}
+
+func TestDecodeCLValue_Nested(t *testing.T) {
+	clType := MapCLType(SimpleCLType(CLTypeString), ListCLType(SimpleCLType(CLTypeKey)))
+	value := "01000000" + "0400000074657374" + "02000000" +
+		"004c61453f1bdf1f3c4b20b47b2fcfedabcc9e3afb29f8bb5983b7184e6a4497e5" +
+		"024c61453f1bdf1f3c4b20b47b2fcfedabcc9e3afb29f8bb5983b7184e6a4497e507"
+	src, _ := hex.DecodeString(value)
+
+	result, err := DecodeCLValue(src, clType)
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	keys := *result.Map.Raw["test"].List
+	assert.Len(t, keys, 2)
+	assert.Equal(t, KeyTypeAccount, keys[0].Key.Type)
+	assert.Equal(t, getAddress(), keys[0].Key.Account)
+	assert.Equal(t, KeyTypeURef, keys[1].Key.Type)
+	assert.Equal(t, AccessRightReadAddWrite, keys[1].Key.URef.AccessRight)
+
+	encoded, err := serialization.Marshal(result)
+	assert.NoError(t, err)
+	assert.Equal(t, value, hex.EncodeToString(encoded))
+}
+
+func TestDecodeCLValue_ResultError(t *testing.T) {
+	clType := ResultCLType(SimpleCLType(CLTypeUnit), SimpleCLType(CLTypeU32))
+	src, _ := hex.DecodeString("0007000000")
+
+	result, err := DecodeCLValue(src, clType)
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	assert.False(t, result.Result.IsSuccess)
+	assert.Equal(t, uint32(7), *result.Result.Error.U32)
+
+	src, _ = hex.DecodeString("01")
+	result, err = DecodeCLValue(src, clType)
+	assert.NoError(t, err)
+	assert.True(t, result.Result.IsSuccess)
+}
+
+func TestDecodeCLValue_ByteArray(t *testing.T) {
+	clType := TupleCLType(ByteArrayCLType(2), SimpleCLType(CLTypeU8))
+	src, _ := hex.DecodeString("010203")
+
+	result, err := DecodeCLValue(src, clType)
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	assert.Equal(t, FixedByteArray{1, 2}, *result.Tuple2[0].ByteArray)
+	assert.Equal(t, uint8(3), *result.Tuple2[1].U8)
+}
+
+func TestDecodeCLValue_PublicKey(t *testing.T) {
+	src, _ := hex.DecodeString("0203b24eb09b295d3122d7abd1aafccb2c899d5db159e73e1c8fc972f017c308a363")
+
+	result, err := DecodeCLValue(src, SimpleCLType(CLTypePublicKey))
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	assert.Equal(t, keypair.KeyTagSecp256k1, result.PublicKey.Tag)
+	assert.Len(t, result.PublicKey.PubKeyData, 33)
+
+	encoded, err := serialization.Marshal(result)
+	assert.NoError(t, err)
+	assert.Equal(t, src, encoded)
+}
+
+func TestDecodeCLValue_ListOfUnit(t *testing.T) {
+	clType := ListCLType(SimpleCLType(CLTypeUnit))
+	src, _ := hex.DecodeString("03000000")
+
+	result, err := DecodeCLValue(src, clType)
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	assert.Len(t, *result.List, 3)
+
+	encoded, err := serialization.Marshal(result)
+	assert.NoError(t, err)
+	assert.Equal(t, src, encoded)
+
+	// a length prefix alone must not allocate billions of elements
+	src, _ = hex.DecodeString("ffffffff")
+	_, err = DecodeCLValue(src, clType)
+	assert.Error(t, err)
+	_, err = DecodeCLValue(src, MapCLType(SimpleCLType(CLTypeUnit), SimpleCLType(CLTypeUnit)))
+	assert.Error(t, err)
+
+	// the elements of a tuple list take two bytes each
+	src, _ = hex.DecodeString("030000000102030405")
+	_, err = DecodeCLValue(src, ListCLType(TupleCLType(SimpleCLType(CLTypeUnit), ByteArrayCLType(2))))
+	assert.True(t, errors.Is(err, errNotEnoughBytes))
+}
+
+func TestDecodeCLValue_Invalid(t *testing.T) {
+	invalid := []struct {
+		Name string
+		Type CLTypeInfo
+		Hex  string
+	}{
+		{"trailing bytes", SimpleCLType(CLTypeU32), "0100000000"},
+		{"short U64", SimpleCLType(CLTypeU64), "01000000"},
+		{"bool", SimpleCLType(CLTypeBool), "02"},
+		{"U512 length", SimpleCLType(CLTypeU512), "41" + "00"},
+		{"U128 length", SimpleCLType(CLTypeU128), "11000000000000000000000000000000000000"},
+		{"string length", SimpleCLType(CLTypeString), "ffffffff00"},
+		{"list length", ListCLType(SimpleCLType(CLTypeU8)), "ffffffff00"},
+		{"map length", MapCLType(SimpleCLType(CLTypeU8), SimpleCLType(CLTypeU8)), "0200000001"},
+		{"option tag", OptionCLType(SimpleCLType(CLTypeU8)), "0201"},
+		{"result tag", ResultCLType(SimpleCLType(CLTypeU8), SimpleCLType(CLTypeU8)), "0201"},
+		{"key tag", SimpleCLType(CLTypeKey), "ff4c61453f1bdf1f3c4b20b47b2fcfedabcc9e3afb29f8bb5983b7184e6a4497e5"},
+		{"short key", SimpleCLType(CLTypeKey), "014c61453f"},
+		{"short uref", SimpleCLType(CLTypeURef), "4c61453f1bdf1f3c4b20b47b2fcfedab"},
+		{"short byte array", ByteArrayCLType(32), "4c61453f"},
+		{"public key tag", SimpleCLType(CLTypePublicKey), "0300"},
+		{"any", SimpleCLType(CLTypeAny), "00"},
+		{"missing inner type", CLTypeInfo{Type: CLTypeList}, "00000000"},
+	}
+
+	for _, c := range invalid {
+		src, _ := hex.DecodeString(c.Hex)
+		_, err := DecodeCLValue(src, c.Type)
+		assert.Error(t, err, c.Name)
+	}
+}
+
+func TestUnmarshalCLValue_Length(t *testing.T) {
+	src, _ := hex.DecodeString("010700000000000000ff")
+
+	result := CLValue{Type: CLTypeOption, Option: &CLValue{Type: CLTypeU64}}
+	n, err := UnmarshalCLValue(src, &result)
+	assert.NoError(t, err)
+	assert.Equal(t, 9, n)
+
+	key := CLValue{Type: CLTypeKey}
+	src, _ = hex.DecodeString("014c61453f1bdf1f3c4b20b47b2fcfedabcc9e3afb29f8bb5983b7184e6a4497e5ff")
+	n, err = UnmarshalCLValue(src, &key)
+	assert.NoError(t, err)
+	assert.Equal(t, 33, n)
+	assert.Equal(t, KeyTypeHash, key.Key.Type)
+
+	// a byte array doesn't take the remaining bytes, its length has to be declared
+	byteArray := CLValue{Type: CLTypeByteArray}
+	_, err = UnmarshalCLValue(src, &byteArray)
+	assert.Error(t, err)
+
+	placeholder := make(FixedByteArray, 2)
+	byteArray.ByteArray = &placeholder
+	n, err = UnmarshalCLValue(src, &byteArray)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, n)
+	assert.Equal(t, FixedByteArray{0x01, 0x4c}, *byteArray.ByteArray)
+}
+
+func TestCLValue_Accessors(t *testing.T) {
+	u512 := CLValue{Type: CLTypeU512, U512: big.NewInt(2500000000)}
+	i, err := u512.AsBigInt()
+	assert.NoError(t, err)
+	assert.Equal(t, "2500000000", i.String())
+
+	i.SetInt64(1)
+	assert.Equal(t, "2500000000", u512.U512.String())
+
+	u8 := CLValue{Type: CLTypeU8, U8: createPtrU8(7)}
+	u, err := u8.AsUint64()
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(7), u)
+
+	_, err = u512.AsString()
+	assert.True(t, errors.Is(err, ErrCLTypeMismatch))
+	assert.EqualError(t, err, "cl type mismatch: expected String, got U512")
+
+	_, err = CLValue{Type: CLTypeString}.AsString()
+	assert.Error(t, err)
+	assert.False(t, errors.Is(err, ErrCLTypeMismatch))
+
+	str, err := CLValue{Type: CLTypeString, String: createPtrString("test")}.AsString()
+	assert.NoError(t, err)
+	assert.Equal(t, "test", str)
+
+	for _, c := range cases {
+		switch c.Value.Type {
+		case CLTypeKey:
+			key, err := c.Value.AsKey()
+			assert.NoError(t, err, c.Name)
+			assert.Equal(t, *c.Value.Key, key, c.Name)
+		case CLTypeList:
+			list, err := c.Value.AsList()
+			assert.NoError(t, err, c.Name)
+			assert.Equal(t, *c.Value.List, list, c.Name)
+		case CLTypeMap:
+			entries, err := c.Value.AsMap()
+			assert.NoError(t, err, c.Name)
+			value, err := entries["test"].AsString()
+			assert.NoError(t, err, c.Name)
+			assert.Equal(t, "test", value, c.Name)
+		case CLTypeTuple2:
+			elements, err := c.Value.AsTuple()
+			assert.NoError(t, err, c.Name)
+			assert.Len(t, elements, 2, c.Name)
+		case CLTypeResult:
+			value, isOk, err := c.Value.AsResult()
+			assert.NoError(t, err, c.Name)
+			assert.Equal(t, c.Value.Result.IsSuccess, isOk, c.Name)
+			if isOk {
+				assert.Equal(t, *c.Value.Result.Success, value, c.Name)
+			}
+		}
+
+		if c.Value.Type != CLTypeList {
+			_, err := c.Value.AsList()
+			assert.True(t, errors.Is(err, ErrCLTypeMismatch), c.Name)
+		}
+	}
+}
+
+func TestCLValue_AsPublicKey(t *testing.T) {
+	src, _ := hex.DecodeString("012bac1d0ff9240ff0b7b06d555815640497861619ca12583ddef434885416e69b")
+	value, err := DecodeCLValue(src, SimpleCLType(CLTypePublicKey))
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	publicKey, err := value.AsPublicKey()
+	assert.NoError(t, err)
+	assert.Equal(t, keypair.KeyTagEd25519, publicKey.Tag)
+
+	_, err = value.AsKey()
+	assert.True(t, errors.Is(err, ErrCLTypeMismatch))
+}
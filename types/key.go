@@ -2,9 +2,17 @@ package types
 
 import (
 	"bytes"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
 	"io"
+	"strconv"
+	"strings"
 
 	"github.com/casper-ecosystem/casper-golang-sdk/serialization"
+	"golang.org/x/crypto/blake2b"
 )
 
 type KeyType byte
@@ -19,8 +27,98 @@ const (
 	KeyTypeBalance
 	KeyTypeBid
 	KeyTypeWithdraw
+	KeyTypeDictionary
+	KeyTypeSystemContractRegistry
+	KeyTypeEraSummary
+	KeyTypeUnbond
+	KeyTypeChainspecRegistry
+	KeyTypeChecksumRegistry
 )
 
+// prefixes of the formatted keys, the URef prefix is URefPrefix
+const (
+	AccountHashPrefix            = "account-hash-"
+	HashPrefix                   = "hash-"
+	TransferPrefix               = "transfer-"
+	DeployInfoPrefix             = "deploy-"
+	EraIdPrefix                  = "era-"
+	BalancePrefix                = "balance-"
+	BidPrefix                    = "bid-"
+	WithdrawPrefix               = "withdraw-"
+	DictionaryPrefix             = "dictionary-"
+	SystemContractRegistryPrefix = "system-contract-registry-"
+	EraSummaryPrefix             = "era-summary-"
+	UnbondPrefix                 = "unbond-"
+	ChainspecRegistryPrefix      = "chainspec-registry-"
+	ChecksumRegistryPrefix       = "checksum-registry-"
+)
+
+// formattedKeyTypes is the order in which the prefixes are matched, era-summary- has to come before era-
+var formattedKeyTypes = []KeyType{
+	KeyTypeAccount,
+	KeyTypeHash,
+	KeyTypeTransfer,
+	KeyTypeDeployInfo,
+	KeyTypeEraSummary,
+	KeyTypeEraId,
+	KeyTypeBalance,
+	KeyTypeBid,
+	KeyTypeWithdraw,
+	KeyTypeDictionary,
+	KeyTypeSystemContractRegistry,
+	KeyTypeUnbond,
+	KeyTypeChainspecRegistry,
+	KeyTypeChecksumRegistry,
+}
+
+// keyPadding is written in place of the address of the singleton keys, e.g. SystemContractRegistry
+var keyPadding [32]byte
+
+func (t KeyType) prefix() string {
+	switch t {
+	case KeyTypeAccount:
+		return AccountHashPrefix
+	case KeyTypeHash:
+		return HashPrefix
+	case KeyTypeURef:
+		return URefPrefix
+	case KeyTypeTransfer:
+		return TransferPrefix
+	case KeyTypeDeployInfo:
+		return DeployInfoPrefix
+	case KeyTypeEraId:
+		return EraIdPrefix
+	case KeyTypeBalance:
+		return BalancePrefix
+	case KeyTypeBid:
+		return BidPrefix
+	case KeyTypeWithdraw:
+		return WithdrawPrefix
+	case KeyTypeDictionary:
+		return DictionaryPrefix
+	case KeyTypeSystemContractRegistry:
+		return SystemContractRegistryPrefix
+	case KeyTypeEraSummary:
+		return EraSummaryPrefix
+	case KeyTypeUnbond:
+		return UnbondPrefix
+	case KeyTypeChainspecRegistry:
+		return ChainspecRegistryPrefix
+	case KeyTypeChecksumRegistry:
+		return ChecksumRegistryPrefix
+	}
+	return ""
+}
+
+// isSingleton reports whether the key type has a single instance, its address is always keyPadding
+func (t KeyType) isSingleton() bool {
+	switch t {
+	case KeyTypeSystemContractRegistry, KeyTypeEraSummary, KeyTypeChainspecRegistry, KeyTypeChecksumRegistry:
+		return true
+	}
+	return false
+}
+
 // Key represents key structure
 type Key struct {
 	Type       KeyType
@@ -33,6 +131,8 @@ type Key struct {
 	Balance    [32]byte
 	Bid        [32]byte
 	Withdraw   [32]byte
+	Dictionary [32]byte
+	Unbond     [32]byte
 }
 
 func (u Key) SwitchFieldName() string {
@@ -59,129 +159,222 @@ func (u Key) ArmForSwitch(sw byte) (string, bool) {
 		return "Bid", true
 	case KeyTypeWithdraw:
 		return "Withdraw", true
+	case KeyTypeDictionary:
+		return "Dictionary", true
+	case KeyTypeUnbond:
+		return "Unbond", true
+	case KeyTypeSystemContractRegistry, KeyTypeEraSummary, KeyTypeChainspecRegistry, KeyTypeChecksumRegistry:
+		return "", true
 	}
 	return "-", false
 }
 
-func (u Key) Marshal(w io.Writer) (int, error) {
-	toMarshal := make([]byte, 1)
-	toMarshal[0] = byte(u.Type)
+// address returns the field holding the 32 bytes address of the key type, nil for URef, EraId and the singleton keys
+func (u *Key) address() *[32]byte {
 	switch u.Type {
 	case KeyTypeAccount:
-		toMarshal = append(toMarshal, u.Account[:]...)
+		return &u.Account
 	case KeyTypeHash:
-		toMarshal = append(toMarshal, u.Hash[:]...)
-	case KeyTypeURef:
+		return &u.Hash
+	case KeyTypeTransfer:
+		return &u.Transfer
+	case KeyTypeDeployInfo:
+		return &u.DeployInfo
+	case KeyTypeBalance:
+		return &u.Balance
+	case KeyTypeBid:
+		return &u.Bid
+	case KeyTypeWithdraw:
+		return &u.Withdraw
+	case KeyTypeDictionary:
+		return &u.Dictionary
+	case KeyTypeUnbond:
+		return &u.Unbond
+	}
+	return nil
+}
+
+func (u Key) Marshal(w io.Writer) (int, error) {
+	toMarshal := make([]byte, 1)
+	toMarshal[0] = byte(u.Type)
+	switch {
+	case u.Type == KeyTypeURef:
+		if u.URef == nil {
+			return 0, errors.New("missing uref of the key")
+		}
 		mashaledURef, err := serialization.Marshal(u.URef)
 		if err != nil {
 			return 0, err
 		}
 
 		toMarshal = append(toMarshal, mashaledURef...)
-	case KeyTypeTransfer:
-		toMarshal = append(toMarshal, u.Transfer[:]...)
-	case KeyTypeDeployInfo:
-		toMarshal = append(toMarshal, u.DeployInfo[:]...)
-	case KeyTypeEraId:
+	case u.Type == KeyTypeEraId:
+		if u.EraId == nil {
+			return 0, errors.New("missing era id of the key")
+		}
 		mashaledEraId, err := serialization.Marshal(*u.EraId)
 		if err != nil {
 			return 0, err
 		}
 
 		toMarshal = append(toMarshal, mashaledEraId...)
-	case KeyTypeBalance:
-		toMarshal = append(toMarshal, u.Balance[:]...)
-	case KeyTypeBid:
-		toMarshal = append(toMarshal, u.Bid[:]...)
-	case KeyTypeWithdraw:
-		toMarshal = append(toMarshal, u.Withdraw[:]...)
+	case u.Type.isSingleton():
+		toMarshal = append(toMarshal, keyPadding[:]...)
+	case u.address() != nil:
+		toMarshal = append(toMarshal, u.address()[:]...)
+	default:
+		return 0, fmt.Errorf("unknown key type %d", u.Type)
 	}
 
 	return w.Write(toMarshal)
 }
 
+// Unmarshal decodes a key starting with its tag byte and returns the number of bytes read
 func (u *Key) Unmarshal(data []byte) (int, error) {
-	r := bytes.NewReader(data)
-	dec := serialization.NewDecoder(r)
+	if len(data) < 1 {
+		return 0, errors.New("inproper source, not enough bytes")
+	}
 
-	switch u.Type {
-	case KeyTypeAccount:
-		destByteArray, i, err := dec.DecodeFixedByteArray(32)
-		if err != nil {
-			return 0, err
-		}
+	u.Type = KeyType(data[0])
+	data = data[1:]
 
-		u.Account = bytesTo32byte(destByteArray)
+	if u.Type == KeyTypeURef {
+		u.URef = &URef{}
+		n, err := u.URef.Unmarshal(data)
+		return n + 1, err
+	}
 
-		return i, nil
-	case KeyTypeHash:
-		destByteArray, i, err := dec.DecodeFixedByteArray(32)
-		if err != nil {
-			return 0, err
+	if u.Type == KeyTypeEraId {
+		if len(data) < 8 {
+			return 1, errors.New("inproper source, not enough bytes")
 		}
+		eraId := binary.LittleEndian.Uint64(data)
+		u.EraId = &eraId
+		return 9, nil
+	}
 
-		u.Hash = bytesTo32byte(destByteArray)
+	dest := u.address()
+	if dest == nil && !u.Type.isSingleton() {
+		return 1, fmt.Errorf("unknown key type %d", u.Type)
+	}
 
-		return i, nil
-	case KeyTypeURef:
-		u.URef = &URef{}
-		return u.URef.Unmarshal(data)
-	case KeyTypeTransfer:
-		destByteArray, i, err := dec.DecodeFixedByteArray(32)
-		if err != nil {
-			return 0, err
-		}
+	if len(data) < 32 {
+		return 1, errors.New("inproper source, not enough bytes")
+	}
+	if dest != nil {
+		copy(dest[:], data)
+	} else if !bytes.Equal(data[:32], keyPadding[:]) {
+		return 1, fmt.Errorf("invalid %skey, the address has to be zero", u.Type.prefix())
+	}
 
-		u.Transfer = bytesTo32byte(destByteArray)
+	return 33, nil
+}
 
-		return i, nil
-	case KeyTypeDeployInfo:
-		destByteArray, i, err := dec.DecodeFixedByteArray(32)
-		if err != nil {
-			return 0, err
+// NewDictionaryKey returns the Dictionary key of an item, its address is the blake2b hash of the address of the
+// dictionary seed uref followed by the item key
+func NewDictionaryKey(seedURef URef, dictionaryItemKey string) Key {
+	hash, _ := blake2b.New256(nil)
+	hash.Write(seedURef.Address[:])
+	hash.Write([]byte(dictionaryItemKey))
+
+	key := Key{Type: KeyTypeDictionary}
+	copy(key.Dictionary[:], hash.Sum(nil))
+	return key
+}
+
+// ToFormattedString returns the key in the form used by the node, e.g. account-hash-<hex> or era-<id>.
+// It returns an empty string for unknown key types
+func (u Key) ToFormattedString() string {
+	switch {
+	case u.Type == KeyTypeURef:
+		if u.URef == nil {
+			return URef{}.ToFormattedString()
+		}
+		return u.URef.ToFormattedString()
+	case u.Type == KeyTypeEraId:
+		var eraId uint64
+		if u.EraId != nil {
+			eraId = *u.EraId
 		}
+		return EraIdPrefix + strconv.FormatUint(eraId, 10)
+	case u.Type.isSingleton():
+		return u.Type.prefix() + hex.EncodeToString(keyPadding[:])
+	case u.address() != nil:
+		return u.Type.prefix() + hex.EncodeToString(u.address()[:])
+	}
 
-		u.DeployInfo = bytesTo32byte(destByteArray)
+	return ""
+}
 
-		return i, nil
-	case KeyTypeEraId:
-		tempInt, n, err := dec.DecodeUInt64()
+// KeyFromFormattedString parses a key in the form used by the node, e.g. hash-<hex> or uref-<hex>-007
+func KeyFromFormattedString(str string) (*Key, error) {
+	if strings.HasPrefix(str, URefPrefix) {
+		uRef, err := URefFromFormattedString(str)
 		if err != nil {
-			return n, err
+			return nil, err
 		}
+		return &Key{Type: KeyTypeURef, URef: uRef}, nil
+	}
 
-		u.EraId = &tempInt
-		return n, nil
-	case KeyTypeBalance:
-		destByteArray, i, err := dec.DecodeFixedByteArray(32)
-		if err != nil {
-			return 0, err
+	for _, keyType := range formattedKeyTypes {
+		prefix := keyType.prefix()
+		if !strings.HasPrefix(str, prefix) {
+			continue
 		}
 
-		u.Balance = bytesTo32byte(destByteArray)
+		result := Key{Type: keyType}
+		value := str[len(prefix):]
 
-		return i, nil
-	case KeyTypeBid:
-		destByteArray, i, err := dec.DecodeFixedByteArray(32)
-		if err != nil {
-			return 0, err
+		if keyType == KeyTypeEraId {
+			eraId, err := strconv.ParseUint(value, 10, 64)
+			if err != nil {
+				return nil, fmt.Errorf("invalid era id: %w", err)
+			}
+			result.EraId = &eraId
+			return &result, nil
 		}
 
-		u.Bid = bytesTo32byte(destByteArray)
-
-		return i, nil
-	case KeyTypeWithdraw:
-		destByteArray, i, err := dec.DecodeFixedByteArray(32)
+		decoded, err := hex.DecodeString(value)
 		if err != nil {
-			return 0, err
+			return nil, err
+		}
+		if len(decoded) != 32 {
+			return nil, errors.New("invalid address length")
+		}
+
+		if dest := result.address(); dest != nil {
+			copy(dest[:], decoded)
+		} else if !bytes.Equal(decoded, keyPadding[:]) {
+			return nil, fmt.Errorf("invalid %skey, the address has to be zero", prefix)
 		}
 
-		u.Withdraw = bytesTo32byte(destByteArray)
+		return &result, nil
+	}
+
+	return nil, fmt.Errorf("unknown key prefix: %q", str)
+}
+
+func (u Key) MarshalJSON() ([]byte, error) {
+	formatted := u.ToFormattedString()
+	if formatted == "" {
+		return nil, fmt.Errorf("unknown key type %d", u.Type)
+	}
+	return json.Marshal(formatted)
+}
+
+func (u *Key) UnmarshalJSON(data []byte) error {
+	var formatted string
+	if err := json.Unmarshal(data, &formatted); err != nil {
+		return err
+	}
 
-		return i, nil
+	key, err := KeyFromFormattedString(formatted)
+	if err != nil {
+		return err
 	}
 
-	return 0, nil
+	*u = *key
+	return nil
 }
 
 func bytesTo32byte(input []byte) [32]byte {
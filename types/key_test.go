@@ -0,0 +1,135 @@
+package types
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/casper-ecosystem/casper-golang-sdk/serialization"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestKey_FormattedString(t *testing.T) {
+	address := strings.Repeat("2a", 32)
+	zero := strings.Repeat("00", 32)
+
+	testCases := []struct {
+		formatted string
+		keyType   KeyType
+	}{
+		{"account-hash-" + address, KeyTypeAccount},
+		{"hash-" + address, KeyTypeHash},
+		{"uref-" + address + "-007", KeyTypeURef},
+		{"transfer-" + address, KeyTypeTransfer},
+		{"deploy-" + address, KeyTypeDeployInfo},
+		{"era-42", KeyTypeEraId},
+		{"balance-" + address, KeyTypeBalance},
+		{"bid-" + address, KeyTypeBid},
+		{"withdraw-" + address, KeyTypeWithdraw},
+		{"dictionary-" + address, KeyTypeDictionary},
+		{"system-contract-registry-" + zero, KeyTypeSystemContractRegistry},
+		{"era-summary-" + zero, KeyTypeEraSummary},
+		{"unbond-" + address, KeyTypeUnbond},
+		{"chainspec-registry-" + zero, KeyTypeChainspecRegistry},
+		{"checksum-registry-" + zero, KeyTypeChecksumRegistry},
+	}
+
+	for _, testCase := range testCases {
+		key, err := KeyFromFormattedString(testCase.formatted)
+		if !assert.NoError(t, err, testCase.formatted) {
+			continue
+		}
+		assert.Equal(t, testCase.keyType, key.Type, testCase.formatted)
+		assert.Equal(t, testCase.formatted, key.ToFormattedString())
+
+		marshaled, err := serialization.Marshal(*key)
+		assert.NoError(t, err, testCase.formatted)
+		assert.Equal(t, byte(testCase.keyType), marshaled[0])
+
+		var unmarshaled Key
+		n, err := unmarshaled.Unmarshal(marshaled)
+		assert.NoError(t, err, testCase.formatted)
+		assert.Equal(t, len(marshaled), n)
+		assert.Equal(t, *key, unmarshaled)
+	}
+}
+
+func TestKey_NewVariantBytes(t *testing.T) {
+	key := Key{Type: KeyTypeDictionary}
+	for i := range key.Dictionary {
+		key.Dictionary[i] = 0x2a
+	}
+
+	marshaled, err := serialization.Marshal(key)
+	assert.NoError(t, err)
+	assert.Equal(t, "09"+strings.Repeat("2a", 32), hex.EncodeToString(marshaled))
+
+	marshaled, err = serialization.Marshal(Key{Type: KeyTypeSystemContractRegistry})
+	assert.NoError(t, err)
+	assert.Equal(t, "0a"+strings.Repeat("00", 32), hex.EncodeToString(marshaled))
+
+	_, err = serialization.Marshal(Key{Type: KeyType(99)})
+	assert.Error(t, err)
+
+	var parsed Key
+	n, err := parsed.Unmarshal(marshaled)
+	assert.NoError(t, err)
+	assert.Equal(t, 33, n)
+	assert.Equal(t, KeyTypeSystemContractRegistry, parsed.Type)
+
+	// the singleton keys are rejected unless the address is zero, same as in KeyFromFormattedString
+	marshaled[32] = 0x01
+	_, err = parsed.Unmarshal(marshaled)
+	assert.Error(t, err)
+}
+
+func TestKey_InvalidFormattedString(t *testing.T) {
+	for _, formatted := range []string{
+		"",
+		"unknown-" + strings.Repeat("2a", 32),
+		"hash-" + strings.Repeat("2a", 31),
+		"hash-xyz",
+		"era-summary-" + strings.Repeat("01", 32),
+		"era-abc",
+		"uref-" + strings.Repeat("2a", 32),
+	} {
+		_, err := KeyFromFormattedString(formatted)
+		assert.Error(t, err, formatted)
+	}
+}
+
+func TestKey_JSON(t *testing.T) {
+	formatted := "account-hash-" + strings.Repeat("2a", 32)
+
+	var decoded struct {
+		Key  Key  `json:"key"`
+		URef URef `json:"uref"`
+	}
+	err := json.Unmarshal([]byte(`{"key":"`+formatted+`","uref":"uref-`+strings.Repeat("2a", 32)+`-007"}`), &decoded)
+	assert.NoError(t, err)
+	assert.Equal(t, KeyTypeAccount, decoded.Key.Type)
+	assert.Equal(t, AccessRightReadAddWrite, decoded.URef.AccessRight)
+
+	encoded, err := json.Marshal(decoded)
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"key":"`+formatted+`","uref":"uref-`+strings.Repeat("2a", 32)+`-007"}`, string(encoded))
+
+	assert.Error(t, json.Unmarshal([]byte(`"bid-00"`), &decoded.Key))
+}
+
+func TestNewDictionaryKey(t *testing.T) {
+	seed := URef{AccessRight: AccessRightReadAddWrite}
+	for i := range seed.Address {
+		seed.Address[i] = 0x2a
+	}
+
+	key := NewDictionaryKey(seed, "alice")
+	assert.Equal(t, KeyTypeDictionary, key.Type)
+	assert.Equal(t, "dictionary-e4d32816b394f6d6d8ce2d55caf830a91d40ed9fa1a20ec556635e2662260460", key.ToFormattedString())
+
+	// the access rights of the seed uref don't change the address
+	seed.AccessRight = AccessRightRead
+	assert.Equal(t, key, NewDictionaryKey(seed, "alice"))
+	assert.NotEqual(t, key, NewDictionaryKey(seed, "bob"))
+}
@@ -2,6 +2,7 @@ package types
 
 import (
 	"encoding/hex"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
@@ -58,7 +59,7 @@ func (uRef URef) ToFormattedString() string {
 }
 
 func URefFromFormattedString(str string) (*URef, error) {
-	if str[:len(URefPrefix)] != URefPrefix {
+	if !strings.HasPrefix(str, URefPrefix) {
 		return nil, errors.New("invalid prefix (not 'uref-')")
 	}
 
@@ -92,3 +93,22 @@ func URefFromFormattedString(str string) (*URef, error) {
 
 	return &result, nil
 }
+
+func (uRef URef) MarshalJSON() ([]byte, error) {
+	return json.Marshal(uRef.ToFormattedString())
+}
+
+func (uRef *URef) UnmarshalJSON(data []byte) error {
+	var formatted string
+	if err := json.Unmarshal(data, &formatted); err != nil {
+		return err
+	}
+
+	result, err := URefFromFormattedString(formatted)
+	if err != nil {
+		return err
+	}
+
+	*uRef = *result
+	return nil
+}